@@ -0,0 +1,139 @@
+package zjson
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// GroupBy 按字段值对元素分组，字符串值直接作为分组名，其他值使用规范 JSON 文本，如 `1`、`true`、`{"a":1}`，
+// 因此字符串 "1" 与数字 1 落入同一组；字段缺失的元素与 null 一起归入 "null" 组。
+// 分组内保持原有顺序，对象和数组元素与源数组共享同一个视图
+func (ja *JsonArray) GroupBy(path string) *JsonObject {
+	groups := NewJsonObject()
+	for _, val := range ja.shared() {
+		fieldVal, _ := lookupPath(val, path)
+		key, ok := resolve(fieldVal).(string)
+		if !ok {
+			key = canonicalKey(fieldVal)
+		}
+		group, ok := groups.data[key].(*JsonArray)
+		if !ok {
+			group = ja.derive(nil)
+			groups.data[key] = group
+		}
+		group.data = append(group.data, val)
+	}
+	return groups
+}
+
+// Sum 对字段值求和，无法按 GetFloat 规则转换为数字的值被忽略
+func (ja *JsonArray) Sum(path string) float64 {
+	var sum float64
+	for _, number := range ja.floats(path) {
+		sum += number
+	}
+	return sum
+}
+
+func (ja *JsonArray) Avg(path string) (float64, error) {
+	numbers := ja.floats(path)
+	if len(numbers) == 0 {
+		return 0, noNumericValues(path)
+	}
+	var sum float64
+	for _, number := range numbers {
+		sum += number
+	}
+	return sum / float64(len(numbers)), nil
+}
+
+func (ja *JsonArray) Min(path string) (float64, error) {
+	numbers := ja.floats(path)
+	if len(numbers) == 0 {
+		return 0, noNumericValues(path)
+	}
+	res := numbers[0]
+	for _, number := range numbers[1:] {
+		res = math.Min(res, number)
+	}
+	return res, nil
+}
+
+func (ja *JsonArray) Max(path string) (float64, error) {
+	numbers := ja.floats(path)
+	if len(numbers) == 0 {
+		return 0, noNumericValues(path)
+	}
+	res := numbers[0]
+	for _, number := range numbers[1:] {
+		res = math.Max(res, number)
+	}
+	return res, nil
+}
+
+// Count 统计字段存在且不为 null 的元素数量
+func (ja *JsonArray) Count(path string) int {
+	count := 0
+	for _, val := range ja.snapshot() {
+		if fieldVal, exist := lookupPath(val, path); exist && fieldVal != nil {
+			count++
+		}
+	}
+	return count
+}
+
+// Percentile 计算第 p 百分位数（0-100），相邻值之间线性插值
+func (ja *JsonArray) Percentile(path string, p float64) (float64, error) {
+	if p < 0 || p > 100 || math.IsNaN(p) {
+		return 0, fmt.Errorf("percentile %v out of range [0, 100]", p)
+	}
+	numbers := ja.floats(path)
+	if len(numbers) == 0 {
+		return 0, noNumericValues(path)
+	}
+	sort.Float64s(numbers)
+
+	rank := p / 100 * float64(len(numbers)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return numbers[lower] + (numbers[upper]-numbers[lower])*(rank-float64(lower)), nil
+}
+
+// DistinctValues 返回字段的不同取值，按首次出现顺序排列，缺失字段被忽略
+func (ja *JsonArray) DistinctValues(path string) *JsonArray {
+	res := NewJsonArray()
+	seen := make(map[string]struct{})
+	for _, val := range ja.snapshot() {
+		fieldVal, exist := lookupPath(val, path)
+		if !exist {
+			continue
+		}
+		key := canonicalKey(fieldVal)
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+		res.data = append(res.data, fieldVal)
+	}
+	return res
+}
+
+func (ja *JsonArray) floats(path string) []float64 {
+	var numbers []float64
+	policy := ja.CoercionPolicy()
+	for _, val := range ja.snapshot() {
+		fieldVal, exist := lookupPath(val, path)
+		if !exist {
+			continue
+		}
+		if number, err := toFloat(fieldVal, policy); err == nil {
+			numbers = append(numbers, number)
+		}
+	}
+	return numbers
+}
+
+func noNumericValues(path string) error {
+	return &PathError{Path: path, Expected: "number", Err: ErrTypeMismatch}
+}
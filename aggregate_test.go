@@ -0,0 +1,84 @@
+package zjson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const ordersJSON = `[
+	{"region":"east","amount":10,"item":{"sku":"a"}},
+	{"region":"west","amount":"20.5","item":{"sku":"b"}},
+	{"region":"east","amount":30,"item":{"sku":"a"}},
+	{"region":"north","amount":"n/a"},
+	{"amount":null}
+]`
+
+func TestJsonArray_GroupBy(t *testing.T) {
+	arr, err := ParseToArray(ordersJSON)
+	assert.NoError(t, err)
+
+	groups := arr.GroupBy("region")
+	assert.Equal(t, 4, groups.Length())
+	assert.Equal(t, 2, groups.GetJsonArrayIgnoreError("east").Length())
+	assert.Equal(t, 1, groups.GetJsonArrayIgnoreError("null").Length())
+
+	bySku := arr.GroupBy("item.sku")
+	assert.Equal(t, 30, bySku.GetJsonArrayIgnoreError("a").GetJsonObjectIgnoreError(1).GetIntIgnoreError("amount"))
+}
+
+// 测试字符串值直接作为分组名，其他值使用规范 JSON 文本
+func TestJsonArray_GroupByMixedTypes(t *testing.T) {
+	arr, err := ParseToArray(`[
+		{"k":1},{"k":"1"},{"k":1.0},
+		{"k":true},{"k":"yes"},
+		{"k":null},{},
+		{"k":{"b":1,"a":2}},{"k":[1,"x"]},{"k":"[1,\"x\"]"}
+	]`)
+	assert.NoError(t, err)
+
+	groups := arr.GroupBy("k")
+	assert.Equal(t, 6, groups.Length())
+	assert.Equal(t, 3, groups.GetJsonArrayIgnoreError("1").Length())
+	assert.Equal(t, 1, groups.GetJsonArrayIgnoreError("true").Length())
+	assert.Equal(t, 1, groups.GetJsonArrayIgnoreError("yes").Length())
+	assert.Equal(t, 2, groups.GetJsonArrayIgnoreError("null").Length())
+	assert.Equal(t, 1, groups.GetJsonArrayIgnoreError(`{"a":2,"b":1}`).Length())
+	assert.Equal(t, 2, groups.GetJsonArrayIgnoreError(`[1,"x"]`).Length())
+}
+
+func TestJsonArray_Aggregations(t *testing.T) {
+	arr, err := ParseToArray(ordersJSON)
+	assert.NoError(t, err)
+
+	// 数字字符串参与计算，无法转换的值被忽略
+	assert.Equal(t, 60.5, arr.Sum("amount"))
+	avg, err := arr.Avg("amount")
+	assert.NoError(t, err)
+	assert.InDelta(t, 20.1667, avg, 1e-4)
+
+	minVal, err := arr.Min("amount")
+	assert.NoError(t, err)
+	assert.Equal(t, 10.0, minVal)
+	maxVal, err := arr.Max("amount")
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, maxVal)
+
+	assert.Equal(t, 4, arr.Count("amount"))
+	assert.Equal(t, 3, arr.Count("item"))
+
+	median, err := arr.Percentile("amount", 50)
+	assert.NoError(t, err)
+	assert.Equal(t, 20.5, median)
+	p25, err := arr.Percentile("amount", 25)
+	assert.NoError(t, err)
+	assert.Equal(t, 15.25, p25)
+	_, err = arr.Percentile("amount", 101)
+	assert.Error(t, err)
+
+	assert.JSONEq(t, `["east","west","north"]`, arr.DistinctValues("region").ToJsonStr())
+
+	_, err = arr.Avg("missing")
+	assert.Error(t, err)
+	assert.Equal(t, 0.0, arr.Sum("missing"))
+}
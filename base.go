@@ -1,13 +1,28 @@
 package zjson
 
-import "encoding/json"
-
-var (
-	jsonParser JsonParser = &defaultParser{}
+import (
+	"encoding/json"
+	"sync/atomic"
 )
 
+// globalParser 保存 parserHolder，读写均为原子操作，解析过程中调用 SetParser 不会产生数据竞争
+var globalParser atomic.Value
+
+type parserHolder struct {
+	parser JsonParser
+}
+
+func init() {
+	SetParser(&defaultParser{})
+}
+
+// SetParser 设置默认配置使用的解析器，未指定 Config.Parser 的容器都使用该解析器
 func SetParser(parser JsonParser) {
-	jsonParser = parser
+	globalParser.Store(parserHolder{parser: parser})
+}
+
+func currentParser() JsonParser {
+	return globalParser.Load().(parserHolder).parser
 }
 
 type JsonParser interface {
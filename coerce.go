@@ -0,0 +1,455 @@
+package zjson
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 以下转换规则由 JsonObject 和 JsonArray 的类型化读取方法共用，保证按键和按下标读取的结果一致
+
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	time.DateTime,
+	time.DateOnly,
+}
+
+func getByKey[T any](jo *JsonObject, key, kind string, convert coercer[T]) (T, error) {
+	jo.mu.RLock()
+	defer jo.mu.RUnlock()
+
+	var zero T
+	val, exist := jo.value(key)
+	if !exist {
+		return zero, keyNotFound(key)
+	}
+	res, err := convert(val, jo.coercionPolicy())
+	if err != nil {
+		return zero, keyTypeMismatch(key, kind, val, err)
+	}
+	return res, nil
+}
+
+func getByIndex[T any](ja *JsonArray, index int, kind string, convert coercer[T]) (T, error) {
+	ja.mu.RLock()
+	defer ja.mu.RUnlock()
+
+	var zero T
+	if index < 0 || index >= len(ja.data) {
+		return zero, outOfBounds(index, len(ja.data))
+	}
+	val := resolve(ja.data[index])
+	res, err := convert(val, ja.coercionPolicy())
+	if err != nil {
+		return zero, indexTypeMismatch(index, kind, val, err)
+	}
+	return res, nil
+}
+
+// coercer 按策略转换值，失败时返回的错误说明拒绝转换的规则
+type coercer[T any] func(val any, policy CoercionPolicy) (T, error)
+
+// coercerFor 返回目标类型对应的转换规则及其描述，未内置规则的类型先尝试类型断言再通过 JSON 转换
+func coercerFor[T any]() (coercer[T], string) {
+	var (
+		convert any
+		kind    string
+	)
+	switch any(*new(T)).(type) {
+	case int:
+		convert, kind = coercer[int](toInt), "an integer"
+	case int64:
+		convert, kind = coercer[int64](toInt64), "an integer"
+	case int32:
+		convert, kind = coercer[int32](toInt32), "a 32-bit integer"
+	case uint:
+		convert, kind = coercer[uint](toUint), "an unsigned integer"
+	case uint64:
+		convert, kind = coercer[uint64](toUint64), "an unsigned integer"
+	case float64:
+		convert, kind = coercer[float64](toFloat), "a float"
+	case string:
+		convert, kind = coercer[string](toString), "a string"
+	case bool:
+		convert, kind = coercer[bool](toBool), "a boolean"
+	case time.Time:
+		convert, kind = coercer[time.Time](toTime), "a time"
+	case time.Duration:
+		convert, kind = coercer[time.Duration](toDuration), "a duration"
+	case []byte:
+		convert, kind = coercer[[]byte](toBytes), "base64 encoded bytes"
+	case *JsonObject:
+		convert, kind = coercer[*JsonObject](toObject), "an object"
+	case *JsonArray:
+		convert, kind = coercer[*JsonArray](toArray), "an array"
+	}
+	if convert != nil {
+		return convert.(coercer[T]), kind
+	}
+
+	return func(val any, _ CoercionPolicy) (T, error) {
+		if t, ok := val.(T); ok {
+			return t, nil
+		}
+		var t T
+		jsonStr, err := currentParser().AnyToJsonString(val)
+		if err != nil {
+			return t, err
+		}
+		return t, currentParser().JsonStringToAny(jsonStr, &t)
+	}, fmt.Sprintf("%T", *new(T))
+}
+
+func toInt64(val any, policy CoercionPolicy) (int64, error) {
+	if n, ok := toNumber(val); ok {
+		return numberToInt64(n, policy)
+	}
+	strVal, ok := val.(string)
+	if !ok {
+		return 0, unsupportedType(val)
+	}
+	numStr, err := numericString(strVal, policy)
+	if err != nil {
+		return 0, err
+	}
+	if number, err := strconv.ParseInt(numStr, 10, 64); err == nil {
+		return number, nil
+	}
+	// 支持 "1e3" 这类可以表示整数的浮点数写法，小数和超出 int64 范围的值不截断
+	f, err := strconv.ParseFloat(numStr, 64)
+	if err != nil {
+		return 0, fmt.Errorf("string %q is not a number", strVal)
+	}
+	if f != math.Trunc(f) {
+		return 0, fmt.Errorf("string %q is not an integer", strVal)
+	}
+	if f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, fmt.Errorf("string %q overflows int64", strVal)
+	}
+	return int64(f), nil
+}
+
+func numberToInt64(n number, policy CoercionPolicy) (int64, error) {
+	if n.isInt {
+		return n.i, nil
+	}
+	if policy == CoercionDefault || policy == CoercionLenient {
+		return int64(n.f), nil
+	}
+	if n.f != math.Trunc(n.f) {
+		return 0, fmt.Errorf("%s policy does not truncate fractional number %v", policy, n.f)
+	}
+	if n.f < math.MinInt64 || n.f >= math.MaxInt64 {
+		return 0, fmt.Errorf("%s policy does not allow %v to overflow int64", policy, n.f)
+	}
+	return int64(n.f), nil
+}
+
+func toInt(val any, policy CoercionPolicy) (int, error) {
+	number, err := toInt64(val, policy)
+	return int(number), err
+}
+
+func toInt32(val any, policy CoercionPolicy) (int32, error) {
+	number, err := toInt64(val, policy)
+	if err != nil {
+		return 0, err
+	}
+	if number < math.MinInt32 || number > math.MaxInt32 {
+		return 0, fmt.Errorf("%d overflows int32", number)
+	}
+	return int32(number), nil
+}
+
+func toUint64(val any, policy CoercionPolicy) (uint64, error) {
+	switch v := val.(type) {
+	case uint:
+		return uint64(v), nil
+	case uint64:
+		return v, nil
+	case string:
+		if numStr, err := numericString(v, policy); err == nil {
+			if number, err := strconv.ParseUint(numStr, 10, 64); err == nil {
+				return number, nil
+			}
+		}
+	}
+	number, err := toInt64(val, policy)
+	if err != nil {
+		return 0, err
+	}
+	if number < 0 {
+		return 0, fmt.Errorf("negative number %d cannot be unsigned", number)
+	}
+	return uint64(number), nil
+}
+
+func toUint(val any, policy CoercionPolicy) (uint, error) {
+	number, err := toUint64(val, policy)
+	return uint(number), err
+}
+
+// toFloat 是 GetFloat 使用的转换规则，除严格策略外数字字符串同样可以转换
+func toFloat(val any, policy CoercionPolicy) (float64, error) {
+	if n, ok := toNumber(val); ok {
+		return n.f, nil
+	}
+	strVal, ok := val.(string)
+	if !ok {
+		return 0, unsupportedType(val)
+	}
+	numStr, err := numericString(strVal, policy)
+	if err != nil {
+		return 0, err
+	}
+	number, err := strconv.ParseFloat(numStr, 64)
+	if err != nil {
+		return 0, fmt.Errorf("string %q is not a number", strVal)
+	}
+	return number, nil
+}
+
+// thousandsPattern 匹配整数部分按三位分组的数字，如 "1,000" 和 "-12,345.6"
+var thousandsPattern = regexp.MustCompile(`^[+-]?\d{1,3}(,\d{3})+(\.\d*)?([eE][+-]?\d+)?$`)
+
+// numericString 检查策略是否允许字符串转数字，宽松策略下去除空白和千位分隔符
+func numericString(strVal string, policy CoercionPolicy) (string, error) {
+	switch policy {
+	case CoercionStrict:
+		return "", fmt.Errorf("%s policy does not convert strings to numbers", policy)
+	case CoercionLenient:
+		numStr := strings.TrimSpace(strVal)
+		if !strings.Contains(numStr, ",") {
+			return numStr, nil
+		}
+		if !thousandsPattern.MatchString(numStr) {
+			return "", fmt.Errorf("string %q has invalid thousands separators", strVal)
+		}
+		return strings.ReplaceAll(numStr, ",", ""), nil
+	}
+	return strVal, nil
+}
+
+func toString(val any, policy CoercionPolicy) (string, error) {
+	if strVal, ok := val.(string); ok {
+		return strVal, nil
+	}
+	switch policy {
+	case CoercionStrict:
+		return "", fmt.Errorf("%s policy does not convert %s to string", policy, typeName(val))
+	case CoercionNumericSafe:
+		// 只格式化标量，对象、数组和 null 不再输出 Go 的内部表示
+		if _, ok := toNumber(val); !ok {
+			if _, ok := val.(bool); !ok {
+				return "", fmt.Errorf("%s policy does not convert %s to string", policy, typeName(val))
+			}
+		}
+	}
+	// 对象和数组输出 JSON 文本，避免输出视图的内部结构
+	switch val.(type) {
+	case map[string]any, []any, *JsonObject, *JsonArray, *lazyNode:
+		raw, err := marshalRaw(val)
+		if err != nil {
+			return "", err
+		}
+		return string(raw), nil
+	}
+	return fmt.Sprint(val), nil
+}
+
+func toBool(val any, policy CoercionPolicy) (bool, error) {
+	switch v := val.(type) {
+	case bool:
+		return v, nil
+	case string:
+		if policy == CoercionStrict {
+			return false, fmt.Errorf("%s policy does not convert strings to booleans", policy)
+		}
+		switch v {
+		case "true", "True", "TRUE":
+			return true, nil
+		case "false", "False", "FALSE":
+			return false, nil
+		}
+		if policy == CoercionLenient {
+			switch strings.ToLower(strings.TrimSpace(v)) {
+			case "true", "yes", "y", "on", "1":
+				return true, nil
+			case "false", "no", "n", "off", "0":
+				return false, nil
+			}
+		}
+		return false, fmt.Errorf("string %q is not a boolean", v)
+	}
+	if n, ok := toNumber(val); ok && policy == CoercionLenient {
+		switch n.f {
+		case 1:
+			return true, nil
+		case 0:
+			return false, nil
+		}
+		return false, fmt.Errorf("number %v is not 0 or 1", n.f)
+	}
+	return false, unsupportedType(val)
+}
+
+// toTime 支持 RFC3339 等常见格式的字符串，数字按 Unix 秒处理，严格策略只接受 RFC3339 字符串
+func toTime(val any, policy CoercionPolicy) (time.Time, error) {
+	switch v := val.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		layouts := timeLayouts
+		if policy == CoercionStrict {
+			layouts = timeLayouts[:2]
+		}
+		for _, layout := range layouts {
+			if t, err := time.Parse(layout, strings.TrimSpace(v)); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("string %q is not a supported time format", v)
+	}
+	n, ok := toNumber(val)
+	if !ok {
+		return time.Time{}, unsupportedType(val)
+	}
+	if policy == CoercionStrict {
+		return time.Time{}, fmt.Errorf("%s policy does not convert numbers to time", policy)
+	}
+	if n.isInt {
+		return time.Unix(n.i, 0), nil
+	}
+	sec, frac := math.Modf(n.f)
+	return time.Unix(int64(sec), int64(frac*1e9)), nil
+}
+
+// toDuration 支持 "1m30s" 形式的字符串，数字与 time.Duration 序列化结果一致按纳秒处理
+func toDuration(val any, policy CoercionPolicy) (time.Duration, error) {
+	switch v := val.(type) {
+	case time.Duration:
+		return v, nil
+	case string:
+		d, err := time.ParseDuration(strings.TrimSpace(v))
+		if err != nil {
+			return 0, fmt.Errorf("string %q is not a duration", v)
+		}
+		return d, nil
+	}
+	if policy == CoercionStrict {
+		return 0, fmt.Errorf("%s policy does not convert %s to duration", policy, typeName(val))
+	}
+	number, err := toInt64(val, policy)
+	return time.Duration(number), err
+}
+
+// toBytes 与 encoding/json 对 []byte 的处理一致，字符串按标准 base64 解码
+func toBytes(val any, _ CoercionPolicy) ([]byte, error) {
+	switch v := val.(type) {
+	case []byte:
+		return v, nil
+	case json.RawMessage:
+		return v, nil
+	case string:
+		b, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return nil, fmt.Errorf("string is not valid base64: %v", err)
+		}
+		return b, nil
+	}
+	return nil, unsupportedType(val)
+}
+
+func toObject(val any, _ CoercionPolicy) (*JsonObject, error) {
+	return ParseToJsonObject(val)
+}
+
+func toArray(val any, _ CoercionPolicy) (*JsonArray, error) {
+	return ParseToArray(val)
+}
+
+// convertObject 转换 GetJsonObject 读到的非 map 值：字符串按 JSON 文本解析，
+// 数字、布尔值、null 和数组以及无法解析的字符串通过 mismatch 返回类型不匹配
+func convertObject(val any, cfg *Config, mismatch func(reason error) error) (*JsonObject, error) {
+	switch val.(type) {
+	case []any, *JsonArray:
+		return nil, mismatch(unsupportedType(val))
+	}
+	if isScalar(val) {
+		return nil, mismatch(unsupportedType(val))
+	}
+	obj, err := parseObject(val, cfg)
+	if _, ok := val.(string); ok && err != nil {
+		return nil, mismatch(err)
+	}
+	return obj, err
+}
+
+func convertArray(val any, cfg *Config, mismatch func(reason error) error) (*JsonArray, error) {
+	switch val.(type) {
+	case map[string]any, *JsonObject:
+		return nil, mismatch(unsupportedType(val))
+	}
+	if isScalar(val) {
+		return nil, mismatch(unsupportedType(val))
+	}
+	arr, err := parseArray(val, cfg)
+	if _, ok := val.(string); ok && err != nil {
+		return nil, mismatch(err)
+	}
+	return arr, err
+}
+
+// isScalar 判断是否为数字、布尔值或 null
+func isScalar(val any) bool {
+	if _, ok := toNumber(val); ok {
+		return true
+	}
+	switch val.(type) {
+	case nil, bool:
+		return true
+	}
+	return false
+}
+
+func unsupportedType(val any) error {
+	return fmt.Errorf("unsupported type %s", typeName(val))
+}
+
+// typeName 返回值对应的 JSON 类型名称，非 JSON 类型返回 Go 类型名称
+func typeName(val any) string {
+	if _, ok := toNumber(val); ok {
+		return "number"
+	}
+	switch normalizeValue(val).(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	}
+	return fmt.Sprintf("%T", val)
+}
+
+// marshalRaw 对实现了 json.Marshaler 的值直接使用其输出，保留延迟解析节点的原始字节
+func marshalRaw(val any) (json.RawMessage, error) {
+	return marshalWith(currentParser(), val)
+}
+
+func marshalWith(parser JsonParser, val any) ([]byte, error) {
+	if marshaler, ok := val.(json.Marshaler); ok {
+		return marshaler.MarshalJSON()
+	}
+	return parser.AnyToJsonString(val)
+}
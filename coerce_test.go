@@ -0,0 +1,107 @@
+package zjson
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 同一组值分别按键和按下标读取，结果必须一致
+func TestTypedGetters_Parity(t *testing.T) {
+	obj, err := ParseToJsonObject(`{
+		"bool":"TRUE","exp":"1e3","big":"9007199254740993","neg":-5,"wide":3000000000,
+		"time":"2024-05-01T10:00:00Z","unix":1714557600,"duration":"1m30s","nanos":1500,
+		"bytes":"aGVsbG8=","nested":{"a":[1,2]}
+	}`)
+	assert.NoError(t, err)
+	keys := []string{"bool", "exp", "big", "neg", "wide", "time", "unix", "duration", "nanos", "bytes", "nested"}
+	arr := NewJsonArray()
+	for _, key := range keys {
+		arr.Add(obj.Get(key))
+	}
+
+	for i, key := range keys {
+		b1, e1 := obj.GetBool(key)
+		b2, e2 := arr.GetBool(i)
+		assert.Equal(t, b1, b2, key)
+		assert.Equal(t, e1 == nil, e2 == nil, key)
+
+		i1, e1 := obj.GetInt64(key)
+		i2, e2 := arr.GetInt64(i)
+		assert.Equal(t, i1, i2, key)
+		assert.Equal(t, e1 == nil, e2 == nil, key)
+
+		u1, e1 := obj.GetUint(key)
+		u2, e2 := arr.GetUint(i)
+		assert.Equal(t, u1, u2, key)
+		assert.Equal(t, e1 == nil, e2 == nil, key)
+
+		t1, e1 := obj.GetTime(key)
+		t2, e2 := arr.GetTime(i)
+		assert.True(t, t1.Equal(t2), key)
+		assert.Equal(t, e1 == nil, e2 == nil, key)
+	}
+
+	assert.True(t, obj.GetBoolIgnoreError("bool"))
+	assert.Equal(t, 1000, obj.GetIntIgnoreError("exp"))
+	assert.Equal(t, int64(1000), arr.GetInt64IgnoreError(1))
+	assert.Equal(t, int32(-5), obj.GetInt32IgnoreError("neg"))
+	_, err = obj.GetInt32("wide")
+	assert.Error(t, err)
+	_, err = obj.GetUint("neg")
+	assert.Error(t, err)
+
+	expected := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	assert.True(t, expected.Equal(obj.GetTimeIgnoreError("time")))
+	assert.True(t, expected.Equal(arr.GetTimeIgnoreError(6)))
+	assert.Equal(t, 90*time.Second, obj.GetDurationIgnoreError("duration"))
+	assert.Equal(t, 1500*time.Nanosecond, arr.GetDurationIgnoreError(8))
+	assert.Equal(t, []byte("hello"), obj.GetBytesIgnoreError("bytes"))
+	assert.Equal(t, []byte("hello"), arr.GetBytesIgnoreError(9))
+	_, err = arr.GetBytes(3)
+	assert.Error(t, err)
+}
+
+func TestTypedGetters_Raw(t *testing.T) {
+	obj, err := ParseToJsonObjectLazy(`{"a":{"b": [1, 2]},"s":"x"}`)
+	assert.NoError(t, err)
+
+	raw, err := obj.GetRaw("a")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"b": [1, 2]}`, string(raw))
+
+	raw, err = obj.GetRaw("s")
+	assert.NoError(t, err)
+	assert.Equal(t, `"x"`, string(raw))
+	_, err = obj.GetRaw("missing")
+	assert.Error(t, err)
+
+	arr := obj.GetJsonObjectIgnoreError("a").GetJsonArrayIgnoreError("b")
+	raw, err = arr.GetRaw(1)
+	assert.NoError(t, err)
+	assert.Equal(t, `2`, string(raw))
+	_, err = arr.GetRaw(2)
+	assert.Error(t, err)
+}
+
+// 测试字符串形式的浮点数只有是整数且在 int64 范围内时才能转换为整数
+func TestToInt64_FloatString(t *testing.T) {
+	obj, err := ParseToJsonObject(`{"exp":"1e3","frac":"12.9","huge":"1e30","neg":"-1e30"}`)
+	assert.NoError(t, err)
+
+	for _, policy := range []CoercionPolicy{CoercionDefault, CoercionLenient, CoercionNumericSafe} {
+		obj.SetCoercionPolicy(policy)
+		n, err := obj.GetInt64("exp")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1000), n)
+
+		_, err = obj.GetInt64("frac")
+		assert.ErrorIs(t, err, ErrTypeMismatch, policy)
+		assert.ErrorContains(t, err, `string "12.9" is not an integer`)
+		_, err = obj.GetInt64("huge")
+		assert.ErrorContains(t, err, `string "1e30" overflows int64`)
+		_, err = obj.GetInt("neg")
+		assert.ErrorIs(t, err, ErrTypeMismatch)
+	}
+}
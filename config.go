@@ -0,0 +1,114 @@
+package zjson
+
+// NumberMode 控制解析时数字的表示方式
+type NumberMode int
+
+const (
+	// NumberFloat64 与 encoding/json 一致，所有数字解析为 float64
+	NumberFloat64 NumberMode = iota
+	// NumberJSON 解析为 json.Number，保留原始文本和精度
+	NumberJSON
+	// NumberInt64 能用 int64 表示的整数解析为 int64，其余数字解析为 float64
+	NumberInt64
+)
+
+// KeyOrder 控制对象键的遍历和序列化顺序
+type KeyOrder int
+
+const (
+	// KeysSorted 按键名排序，与 encoding/json 的序列化结果一致
+	KeysSorted KeyOrder = iota
+	// KeysInsertion 保持输入中的顺序，之后新增的键追加在末尾
+	KeysInsertion
+)
+
+// Config 保存一组解析和读取设置，通过它创建的容器及其子节点共享同一份设置，互不影响其他容器。
+// 零值表示使用全局默认设置，即 SetParser 和 SetCoercionPolicy 设置的值。
+//
+// JsonParser 接口无法控制数字表示、键顺序和解析过程，Numbers、KeyOrder、Limits、重复键或字符串处理
+// 不是默认值时使用内置解析器解析输入，Parser 仍用于 ToStruct 以及默认 InvalidUTF8 策略下的序列化。
+type Config struct {
+	// Parser 为 nil 时使用 SetParser 设置的解析器
+	Parser   JsonParser
+	Numbers  NumberMode
+	KeyOrder KeyOrder
+	// CoercionPolicy 为 0 时使用全局策略，容器通过 SetCoercionPolicy 单独设置的策略优先
+	CoercionPolicy CoercionPolicy
+	Limits         Limits
+	DuplicateKeys  DuplicateKeyPolicy
+	// OnDuplicateKey 在每次遇到重复键时调用，与 DuplicateKeys 策略无关
+	OnDuplicateKey func(DuplicateKey)
+	InvalidUTF8    InvalidUTF8Policy
+	// NormalizeNFC 为 true 时解析得到的键和字符串值都转换为 NFC 形式
+	NormalizeNFC bool
+}
+
+func (c Config) NewJsonObject() *JsonObject {
+	return &JsonObject{data: make(map[string]any), cfg: &c}
+}
+
+func (c Config) NewJsonArray() *JsonArray {
+	return &JsonArray{data: make([]any, 0), cfg: &c}
+}
+
+func (c Config) ParseToJsonObject(v any) (*JsonObject, error) {
+	return parseObject(v, &c)
+}
+
+func (c Config) ParseToArray(v any) (*JsonArray, error) {
+	return parseArray(v, &c)
+}
+
+// ParseToJsonObjectLazy 与包级函数相同，嵌套的对象和数组在首次访问时按该配置解析，
+// 限制、重复键和字符串处理的错误也在访问时返回。MaxNodes 分别统计每一次解析的值，
+// 嵌套节点错误中的偏移和行列号相对于该节点的原始文本
+func (c Config) ParseToJsonObjectLazy(v any) (*JsonObject, error) {
+	return lazyObject(v, &c)
+}
+
+func (c Config) ParseToArrayLazy(v any) (*JsonArray, error) {
+	return lazyArray(v, &c)
+}
+
+// Config 返回容器创建时使用的设置，通过包级函数创建的容器返回零值
+func (jo *JsonObject) Config() Config {
+	if jo.cfg == nil {
+		return Config{}
+	}
+	return *jo.cfg
+}
+
+func (ja *JsonArray) Config() Config {
+	if ja.cfg == nil {
+		return Config{}
+	}
+	return *ja.cfg
+}
+
+func (c *Config) parser() JsonParser {
+	if c == nil || c.Parser == nil {
+		return currentParser()
+	}
+	return c.Parser
+}
+
+// builtin 判断是否需要使用内置解析器
+func (c *Config) builtin() bool {
+	if c == nil {
+		return false
+	}
+	return c.Numbers != NumberFloat64 || c.KeyOrder != KeysSorted || c.Limits.enabled() ||
+		c.DuplicateKeys != DuplicateLastWins || c.OnDuplicateKey != nil ||
+		c.InvalidUTF8 != UTF8Replace || c.NormalizeNFC
+}
+
+func (c *Config) ordered() bool {
+	return c != nil && c.KeyOrder == KeysInsertion
+}
+
+func (c *Config) coercionPolicy() CoercionPolicy {
+	if c == nil || c.CoercionPolicy == 0 {
+		return GetCoercionPolicy()
+	}
+	return c.CoercionPolicy
+}
@@ -0,0 +1,243 @@
+// Package config 将多个来源的配置合并为一个 JsonObject，并记录每个值的来源
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/codinglz/zjson"
+)
+
+// Loader 按顺序加载来源，靠后的来源优先级更高：对象按键递归合并，数组和标量整体覆盖
+type Loader struct {
+	sources  []Source
+	resolver *Resolver
+}
+
+func New(sources ...Source) *Loader {
+	return &Loader{sources: sources}
+}
+
+// WithResolver 设置合并完成后用于展开表达式的 Resolver，Watch 重新加载时同样生效
+func (l *Loader) WithResolver(r *Resolver) *Loader {
+	l.resolver = r
+	return l
+}
+
+func (l *Loader) Load() (*Snapshot, error) {
+	snap := &Snapshot{obj: zjson.NewJsonObject(), origins: make(map[string]string)}
+	for _, source := range l.sources {
+		obj, err := source.Load()
+		if err != nil {
+			return nil, fmt.Errorf("config: load %s: %w", source.Name(), err)
+		}
+		if _, err := snap.obj.DeepMerge(obj); err != nil {
+			return nil, fmt.Errorf("config: merge %s: %w", source.Name(), err)
+		}
+		for _, path := range leafPaths(obj, "") {
+			snap.setOrigin(path, source.Name())
+		}
+	}
+	if l.resolver != nil {
+		return snap.Resolve(l.resolver)
+	}
+	return snap, nil
+}
+
+// Snapshot 是一次加载的结果，加载完成后不应再修改
+type Snapshot struct {
+	obj *zjson.JsonObject
+	// origins 记录每个叶子路径的来源，数组视为叶子
+	origins map[string]string
+}
+
+// Object 返回合并后的对象，与 Snapshot 共享数据，调用方不应修改
+func (s *Snapshot) Object() *zjson.JsonObject {
+	return s.obj
+}
+
+// Get 按点号分隔的路径读取值，数组使用数字下标
+func (s *Snapshot) Get(path string) (any, bool) {
+	return lookup(s.obj, path)
+}
+
+// Source 返回提供该叶子路径的来源名称，对象路径的值可能来自多个来源，此时返回 false
+func (s *Snapshot) Source(path string) (string, bool) {
+	name, ok := s.origins[path]
+	return name, ok
+}
+
+// Sources 返回所有叶子路径及其来源
+func (s *Snapshot) Sources() map[string]string {
+	res := make(map[string]string, len(s.origins))
+	for path, name := range s.origins {
+		res[path] = name
+	}
+	return res
+}
+
+// Bind 通过 ToStruct 将配置绑定到结构体，字段为数字或布尔类型而值为字符串时（如来自 Env 和 Flags）先按字段类型转换
+func (s *Snapshot) Bind(v any) error {
+	typ := reflect.TypeOf(v)
+	if typ == nil {
+		return s.obj.ToStruct(v)
+	}
+	var data map[string]any
+	if err := s.obj.ToStruct(&data); err != nil {
+		return err
+	}
+	obj, err := zjson.Config{Numbers: zjson.NumberJSON}.ParseToJsonObject(coerceStrings(data, typ))
+	if err != nil {
+		return err
+	}
+	return obj.ToStruct(v)
+}
+
+// coerceStrings 按目标类型将字符串转换为数字或布尔值，无法转换时保持原值，由 ToStruct 报告错误
+func coerceStrings(val any, typ reflect.Type) any {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch v := val.(type) {
+	case string:
+		switch typ.Kind() {
+		case reflect.Bool:
+			if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+				return b
+			}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			num := strings.TrimSpace(v)
+			if num != "" && (num[0] == '-' || (num[0] >= '0' && num[0] <= '9')) && json.Valid([]byte(num)) {
+				return json.Number(num)
+			}
+		}
+	case map[string]any:
+		switch typ.Kind() {
+		case reflect.Struct:
+			coerceFields(v, typ)
+		case reflect.Map:
+			for key, item := range v {
+				v[key] = coerceStrings(item, typ.Elem())
+			}
+		}
+	case []any:
+		if typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array {
+			for i, item := range v {
+				v[i] = coerceStrings(item, typ.Elem())
+			}
+		}
+	}
+	return val
+}
+
+// coerceFields 按 encoding/json 的规则匹配字段名，键名不区分大小写，嵌入的结构体字段展开到同一层
+func coerceFields(data map[string]any, typ reflect.Type) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				coerceFields(data, embedded)
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+		for key, item := range data {
+			if strings.EqualFold(key, name) {
+				data[key] = coerceStrings(item, field.Type)
+			}
+		}
+	}
+}
+
+// setOrigin 记录新的来源，并移除被覆盖的子路径或父路径的记录
+func (s *Snapshot) setOrigin(path, name string) {
+	for existing := range s.origins {
+		if strings.HasPrefix(existing, path+".") || strings.HasPrefix(path, existing+".") {
+			delete(s.origins, existing)
+		}
+	}
+	s.origins[path] = name
+}
+
+func leafPaths(obj *zjson.JsonObject, prefix string) []string {
+	var paths []string
+	for key, val := range obj.All() {
+		path := joinPath(prefix, key)
+		if child, ok := asObject(obj, key, val); ok {
+			paths = append(paths, leafPaths(child, path)...)
+			continue
+		}
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func asObject(parent *zjson.JsonObject, key string, val any) (*zjson.JsonObject, bool) {
+	switch val.(type) {
+	case map[string]any, *zjson.JsonObject:
+		child, err := parent.GetJsonObject(key)
+		return child, err == nil
+	}
+	return nil, false
+}
+
+func lookup(obj *zjson.JsonObject, path string) (any, bool) {
+	var val any = obj
+	for _, segment := range strings.Split(path, ".") {
+		switch v := val.(type) {
+		case *zjson.JsonObject:
+			item, exist := v.Lookup(segment)
+			if !exist {
+				return nil, false
+			}
+			if _, ok := item.(map[string]any); ok {
+				item, _ = v.GetJsonObject(segment)
+			} else if _, ok := item.([]any); ok {
+				item, _ = v.GetJsonArray(segment)
+			}
+			val = item
+		case *zjson.JsonArray:
+			index, err := strconv.Atoi(segment)
+			if err != nil {
+				return nil, false
+			}
+			item, exist := v.Lookup(index)
+			if !exist {
+				return nil, false
+			}
+			if _, ok := item.(map[string]any); ok {
+				item, _ = v.GetJsonObject(index)
+			} else if _, ok := item.([]any); ok {
+				item, _ = v.GetJsonArray(index)
+			}
+			val = item
+		default:
+			return nil, false
+		}
+	}
+	return val, true
+}
+
+func joinPath(path, segment string) string {
+	if path == "" {
+		return segment
+	}
+	return path + "." + segment
+}
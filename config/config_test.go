@@ -0,0 +1,143 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+type serverConfig struct {
+	Server struct {
+		Host string `json:"host"`
+		Port int    `json:"port"`
+	} `json:"server"`
+	Debug bool     `json:"debug"`
+	Tags  []string `json:"tags"`
+	Name  string   `json:"name"`
+}
+
+// 测试多个来源按优先级合并并记录来源
+func TestLoader_Precedence(t *testing.T) {
+	dir := t.TempDir()
+	base := writeFile(t, dir, "base.json", `{"server": {"host": "0.0.0.0", "port": 80}, "tags": ["a"], "name": "svc"}`)
+	prod := writeFile(t, dir, "prod.json", `{"server": {"port": 443}, "tags": ["b", "c"]}`)
+	t.Setenv("APP__SERVER__HOST", "10.0.0.1")
+	t.Setenv("APP__DEBUG", "true")
+	t.Setenv("OTHER__DEBUG", "false")
+
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.String("server.port", "", "")
+	flags.String("name", "", "")
+	assert.Nil(t, flags.Parse([]string{"-server.port=8443"}))
+
+	snap, err := New(
+		Defaults(map[string]any{"debug": false, "server": map[string]any{"host": "localhost"}}),
+		File(base),
+		OptionalFile(prod),
+		OptionalFile(filepath.Join(dir, "missing.json")),
+		Env("APP"),
+		Flags(flags),
+	).Load()
+	assert.Nil(t, err)
+
+	var cfg serverConfig
+	assert.Nil(t, snap.Bind(&cfg))
+	assert.Equal(t, "10.0.0.1", cfg.Server.Host)
+	assert.Equal(t, 8443, cfg.Server.Port)
+	assert.True(t, cfg.Debug)
+	assert.Equal(t, []string{"b", "c"}, cfg.Tags)
+	assert.Equal(t, "svc", cfg.Name)
+
+	assert.Equal(t, map[string]string{
+		"debug":       "env",
+		"name":        "file:" + base,
+		"server.host": "env",
+		"server.port": "flags",
+		"tags":        "file:" + prod,
+	}, snap.Sources())
+	source, ok := snap.Source("server.port")
+	assert.True(t, ok)
+	assert.Equal(t, "flags", source)
+	_, ok = snap.Source("server")
+	assert.False(t, ok)
+
+	port, ok := snap.Get("server.port")
+	assert.True(t, ok)
+	assert.Equal(t, "8443", port)
+	assert.Equal(t, 8443, snap.Object().GetJsonObjectIgnoreError("server").GetIntIgnoreError("port"))
+	tag, ok := snap.Get("tags.1")
+	assert.True(t, ok)
+	assert.Equal(t, "c", tag)
+}
+
+// 测试环境变量中的标量保持字符串，绑定时按字段类型转换
+func TestSnapshot_BindEnvStrings(t *testing.T) {
+	t.Setenv("APP__DB__PASSWORD", "12345")
+	t.Setenv("APP__DB__PORT", "5432")
+	t.Setenv("APP__DB__TLS", "true")
+	t.Setenv("APP__NAME", "true")
+	t.Setenv("APP__DB__REPLICAS", `["r1", "r2"]`)
+
+	snap, err := New(Env("APP")).Load()
+	assert.Nil(t, err)
+	password, _ := snap.Get("db.password")
+	assert.Equal(t, "12345", password)
+
+	var cfg struct {
+		Name string `json:"name"`
+		DB   struct {
+			Password string   `json:"password"`
+			Port     *int     `json:"port"`
+			TLS      bool     `json:"tls"`
+			Replicas []string `json:"replicas"`
+		} `json:"db"`
+	}
+	assert.Nil(t, snap.Bind(&cfg))
+	assert.Equal(t, "true", cfg.Name)
+	assert.Equal(t, "12345", cfg.DB.Password)
+	assert.Equal(t, 5432, *cfg.DB.Port)
+	assert.True(t, cfg.DB.TLS)
+	assert.Equal(t, []string{"r1", "r2"}, cfg.DB.Replicas)
+
+	t.Setenv("APP__DB__PORT", "abc")
+	snap, err = New(Env("APP")).Load()
+	assert.Nil(t, err)
+	assert.Error(t, snap.Bind(&cfg))
+}
+
+// 测试覆盖整个子树时移除旧的来源记录
+func TestLoader_OverrideSubtree(t *testing.T) {
+	snap, err := New(
+		Defaults(`{"db": {"host": "localhost", "port": 5432}}`),
+		Defaults(`{"db": "postgres://db"}`),
+	).Load()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"db": "defaults"}, snap.Sources())
+	assert.Equal(t, `{"db":"postgres://db"}`, snap.Object().ToJsonStr())
+}
+
+// 测试加载失败时返回来源名称
+func TestLoader_Errors(t *testing.T) {
+	dir := t.TempDir()
+	_, err := New(File(filepath.Join(dir, "missing.json"))).Load()
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	bad := writeFile(t, dir, "bad.json", `{"a": }`)
+	_, err = New(File(bad)).Load()
+	assert.ErrorContains(t, err, "config: load file:"+bad)
+
+	t.Setenv("APP__DB", "x")
+	t.Setenv("APP__DB__HOST", "y")
+	_, err = New(Env("APP")).Load()
+	assert.ErrorContains(t, err, "config: load env")
+}
@@ -0,0 +1,343 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/codinglz/zjson"
+)
+
+var (
+	ErrUnresolved = errors.New("unresolved reference")
+	ErrCycle      = errors.New("reference cycle")
+)
+
+// ResolveError 描述无法展开的表达式，Path 为包含该表达式的值的路径
+type ResolveError struct {
+	Path string
+	Expr string
+	Err  error
+}
+
+func (e *ResolveError) Error() string {
+	return fmt.Sprintf("config: resolve '%s': %s: %v", e.Path, e.Expr, e.Err)
+}
+
+func (e *ResolveError) Unwrap() error {
+	return e.Err
+}
+
+// SchemeFunc 根据引用返回值，ref 为协议名和 "://" 之后的部分
+type SchemeFunc func(ref string) (string, error)
+
+// Resolver 展开字符串值中的表达式：
+//
+//	${NAME}             先按路径查找其他键，如 ${server.host}，不存在时读取环境变量
+//	${NAME:-default}    NAME 不存在或为空字符串时使用 default，default 中可以继续使用表达式
+//	${scheme://ref}     使用注册的协议读取，默认提供 file 协议，如 ${file:///run/secrets/db}
+//	$${...}             转义，输出 ${...}
+//
+// 整个值为已注册协议的 URI（如 "file:///run/secrets/db"）时同样按协议读取。
+// 整个值只有一个引用其他键的表达式时保留被引用值的类型，否则按字符串拼接，非字符串值使用 JSON 文本。
+type Resolver struct {
+	// LookupEnv 默认为 os.LookupEnv
+	LookupEnv func(key string) (string, bool)
+	schemes   map[string]SchemeFunc
+}
+
+func NewResolver() *Resolver {
+	r := &Resolver{LookupEnv: os.LookupEnv, schemes: make(map[string]SchemeFunc)}
+	r.RegisterScheme("file", readSecretFile)
+	return r
+}
+
+func (r *Resolver) RegisterScheme(name string, fn SchemeFunc) {
+	r.schemes[name] = fn
+}
+
+// readSecretFile 去掉文件末尾的换行符，便于使用 echo 写入的密钥文件
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// Resolve 返回展开后的副本，不修改 obj
+func (r *Resolver) Resolve(obj *zjson.JsonObject) (*zjson.JsonObject, error) {
+	res := &resolution{
+		resolver: r,
+		root:     obj.DeepClone(),
+		done:     make(map[string]any),
+		active:   make(map[string]bool),
+	}
+	for _, path := range stringPaths(res.root, "") {
+		if _, err := res.resolvePath(path); err != nil {
+			return nil, err
+		}
+	}
+	return res.root, nil
+}
+
+// Resolve 返回展开后的新 Snapshot，来源记录保持不变
+func (s *Snapshot) Resolve(r *Resolver) (*Snapshot, error) {
+	obj, err := r.Resolve(s.obj)
+	if err != nil {
+		return nil, err
+	}
+	return &Snapshot{obj: obj, origins: s.Sources()}, nil
+}
+
+type resolution struct {
+	resolver *Resolver
+	root     *zjson.JsonObject
+	// done 缓存已展开的路径，active 为正在展开的路径，chain 按顺序记录用于报告循环
+	done   map[string]any
+	active map[string]bool
+	chain  []string
+}
+
+func (res *resolution) resolvePath(path string) (any, error) {
+	if val, ok := res.done[path]; ok {
+		return val, nil
+	}
+	if res.active[path] {
+		start := 0
+		for i, p := range res.chain {
+			if p == path {
+				start = i
+			}
+		}
+		cycle := append(res.chain[start:len(res.chain):len(res.chain)], path)
+		return nil, &ResolveError{Path: path, Expr: "${" + path + "}", Err: fmt.Errorf("%w: %s", ErrCycle, strings.Join(cycle, " -> "))}
+	}
+
+	val, ok := lookup(res.root, path)
+	if !ok {
+		return nil, &ResolveError{Path: path, Err: ErrUnresolved}
+	}
+	res.active[path] = true
+	res.chain = append(res.chain, path)
+	defer func() {
+		delete(res.active, path)
+		res.chain = res.chain[:len(res.chain)-1]
+	}()
+
+	switch v := val.(type) {
+	case string:
+		resolved, err := res.expand(path, v)
+		if err != nil {
+			return nil, err
+		}
+		if err := setValue(res.root, path, resolved); err != nil {
+			return nil, err
+		}
+		val = resolved
+	case *zjson.JsonObject, *zjson.JsonArray:
+		// 被整体引用的容器需要先展开其中的字符串
+		for _, child := range stringPaths(v, path) {
+			if _, err := res.resolvePath(child); err != nil {
+				return nil, err
+			}
+		}
+	}
+	res.done[path] = val
+	return val, nil
+}
+
+func (res *resolution) expand(path, s string) (any, error) {
+	if scheme, ref, ok := strings.Cut(s, "://"); ok && !strings.Contains(s, "${") {
+		if fn, registered := res.resolver.schemes[scheme]; registered {
+			val, err := fn(ref)
+			if err != nil {
+				return nil, &ResolveError{Path: path, Expr: s, Err: err}
+			}
+			return val, nil
+		}
+	}
+
+	var (
+		sb    strings.Builder
+		whole any
+		parts int
+	)
+	for i := 0; i < len(s); {
+		if strings.HasPrefix(s[i:], "$${") {
+			sb.WriteString("${")
+			i += 3
+			parts++
+			continue
+		}
+		if !strings.HasPrefix(s[i:], "${") {
+			next := strings.Index(s[i+1:], "$")
+			if next < 0 {
+				next = len(s) - i - 1
+			}
+			sb.WriteString(s[i : i+1+next])
+			i += 1 + next
+			parts++
+			continue
+		}
+
+		end := matchingBrace(s, i+2)
+		if end < 0 {
+			return nil, &ResolveError{Path: path, Expr: s[i:], Err: errors.New("missing closing '}'")}
+		}
+		expr := s[i : end+1]
+		val, err := res.eval(path, expr, s[i+2:end])
+		if err != nil {
+			return nil, err
+		}
+		whole = val
+		if err := writeValue(&sb, val); err != nil {
+			return nil, &ResolveError{Path: path, Expr: expr, Err: err}
+		}
+		i = end + 1
+		parts++
+	}
+	if parts == 1 && whole != nil {
+		return whole, nil
+	}
+	return sb.String(), nil
+}
+
+func (res *resolution) eval(path, expr, body string) (any, error) {
+	name, def, hasDef := strings.Cut(body, ":-")
+
+	var (
+		val   any
+		found bool
+	)
+	if scheme, ref, ok := strings.Cut(name, "://"); ok {
+		fn, registered := res.resolver.schemes[scheme]
+		if !registered {
+			return nil, &ResolveError{Path: path, Expr: expr, Err: fmt.Errorf("%w: unknown scheme '%s'", ErrUnresolved, scheme)}
+		}
+		str, err := fn(ref)
+		if err != nil {
+			return nil, &ResolveError{Path: path, Expr: expr, Err: err}
+		}
+		val, found = str, true
+	} else if _, exist := lookup(res.root, name); exist && name != "" {
+		resolved, err := res.resolvePath(name)
+		if err != nil {
+			return nil, err
+		}
+		val, found = resolved, true
+	} else if env, ok := res.resolver.LookupEnv(name); ok {
+		val, found = env, true
+	}
+
+	if hasDef && (!found || val == "") {
+		return res.expand(path, def)
+	}
+	if !found {
+		return nil, &ResolveError{Path: path, Expr: expr, Err: ErrUnresolved}
+	}
+	return val, nil
+}
+
+// matchingBrace 返回与 start 之前的 "${" 匹配的 "}" 位置，支持默认值中嵌套表达式
+func matchingBrace(s string, start int) int {
+	depth := 1
+	for i := start; i < len(s); i++ {
+		switch {
+		case strings.HasPrefix(s[i:], "${"):
+			depth++
+			i++
+		case s[i] == '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func writeValue(sb *strings.Builder, val any) error {
+	if str, ok := val.(string); ok {
+		sb.WriteString(str)
+		return nil
+	}
+	raw, err := json.Marshal(val)
+	if err != nil {
+		return err
+	}
+	sb.Write(raw)
+	return nil
+}
+
+// stringPaths 返回容器中所有字符串值的路径
+func stringPaths(container any, prefix string) []string {
+	var paths []string
+	switch c := container.(type) {
+	case *zjson.JsonObject:
+		for key := range c.Keys() {
+			paths = append(paths, childStringPaths(joinPath(prefix, key), c.Get(key), func() any {
+				return childContainer(c, key)
+			})...)
+		}
+	case *zjson.JsonArray:
+		for i, val := range c.All() {
+			paths = append(paths, childStringPaths(joinPath(prefix, strconv.Itoa(i)), val, func() any {
+				return childContainerAt(c, i)
+			})...)
+		}
+	}
+	return paths
+}
+
+func childStringPaths(path string, val any, container func() any) []string {
+	switch val.(type) {
+	case string:
+		return []string{path}
+	case map[string]any, []any, *zjson.JsonObject, *zjson.JsonArray:
+		return stringPaths(container(), path)
+	}
+	return nil
+}
+
+func childContainer(obj *zjson.JsonObject, key string) any {
+	if child, err := obj.GetJsonObject(key); err == nil {
+		return child
+	}
+	child, _ := obj.GetJsonArray(key)
+	return child
+}
+
+func childContainerAt(arr *zjson.JsonArray, index int) any {
+	if child, err := arr.GetJsonObject(index); err == nil {
+		return child
+	}
+	child, _ := arr.GetJsonArray(index)
+	return child
+}
+
+func setValue(root *zjson.JsonObject, path string, val any) error {
+	parentPath, key := "", path
+	if i := strings.LastIndex(path, "."); i >= 0 {
+		parentPath, key = path[:i], path[i+1:]
+	}
+	var parent any = root
+	if parentPath != "" {
+		parent, _ = lookup(root, parentPath)
+	}
+	switch p := parent.(type) {
+	case *zjson.JsonObject:
+		p.Put(key, val)
+		return nil
+	case *zjson.JsonArray:
+		index, err := strconv.Atoi(key)
+		if err != nil {
+			return err
+		}
+		return p.Set(index, val)
+	}
+	return fmt.Errorf("config: cannot set '%s'", path)
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/codinglz/zjson"
+	"github.com/stretchr/testify/assert"
+)
+
+func testResolver(env map[string]string) *Resolver {
+	r := NewResolver()
+	r.LookupEnv = func(key string) (string, bool) {
+		val, ok := env[key]
+		return val, ok
+	}
+	return r
+}
+
+// 测试环境变量、默认值、键引用和转义
+func TestResolver_Expand(t *testing.T) {
+	obj, _ := zjson.ParseToJsonObject(`{
+		"server": {"host": "${HOST:-localhost}", "port": 8080},
+		"url": "http://${server.host}:${server.port}/${PREFIX:-${name}}",
+		"port": "${server.port}",
+		"name": "svc",
+		"user": "${USER}",
+		"empty": "${EMPTY:-fallback}",
+		"literal": "cost $5 and $${HOME}",
+		"list": ["${name}", {"ref": "${list.0}-x"}]
+	}`)
+	res, err := testResolver(map[string]string{"USER": "admin", "EMPTY": ""}).Resolve(obj)
+	assert.Nil(t, err)
+	assert.Equal(t, "localhost", res.GetJsonObjectIgnoreError("server").GetStringIgnoreError("host"))
+	assert.Equal(t, "http://localhost:8080/svc", res.GetStringIgnoreError("url"))
+	assert.Equal(t, 8080, res.GetIntIgnoreError("port"))
+	assert.Equal(t, "admin", res.GetStringIgnoreError("user"))
+	assert.Equal(t, "fallback", res.GetStringIgnoreError("empty"))
+	assert.Equal(t, "cost $5 and ${HOME}", res.GetStringIgnoreError("literal"))
+	assert.Equal(t, `["svc",{"ref":"svc-x"}]`, res.GetJsonArrayIgnoreError("list").ToJsonStr())
+
+	// 原对象不变
+	assert.Equal(t, "${server.port}", obj.GetStringIgnoreError("port"))
+}
+
+// 测试 file 协议和自定义协议
+func TestResolver_Schemes(t *testing.T) {
+	secret := writeFile(t, t.TempDir(), "db", "s3cret\n")
+	r := testResolver(nil)
+	r.RegisterScheme("vault", func(ref string) (string, error) {
+		if ref == "db/user" {
+			return "root", nil
+		}
+		return "", errors.New("not found")
+	})
+
+	obj, _ := zjson.ParseToJsonObject(`{"db": {
+		"password": "file://` + secret + `",
+		"dsn": "${vault://db/user}:${file://` + secret + `}@db",
+		"site": "https://example.com"
+	}}`)
+	res, err := r.Resolve(obj)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"dsn":"root:s3cret@db","password":"s3cret","site":"https://example.com"}`, res.GetJsonObjectIgnoreError("db").ToJsonStr())
+
+	obj, _ = zjson.ParseToJsonObject(`{"db": {"token": "vault://db/token"}}`)
+	_, err = r.Resolve(obj)
+	assert.EqualError(t, err, "config: resolve 'db.token': vault://db/token: not found")
+
+	obj, _ = zjson.ParseToJsonObject(`{"db": {"token": "${consul://db}"}}`)
+	_, err = r.Resolve(obj)
+	assert.ErrorIs(t, err, ErrUnresolved)
+	assert.ErrorContains(t, err, "unknown scheme 'consul'")
+
+	obj, _ = zjson.ParseToJsonObject(`{"db": {"password": "file:///missing/secret"}}`)
+	_, err = r.Resolve(obj)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+// 测试无法展开的表达式和循环引用
+func TestResolver_Errors(t *testing.T) {
+	r := testResolver(nil)
+
+	obj, _ := zjson.ParseToJsonObject(`{"db": {"url": "postgres://${DB_HOST}/app"}}`)
+	_, err := r.Resolve(obj)
+	assert.ErrorIs(t, err, ErrUnresolved)
+	var resolveErr *ResolveError
+	assert.True(t, errors.As(err, &resolveErr))
+	assert.Equal(t, "db.url", resolveErr.Path)
+	assert.Equal(t, "${DB_HOST}", resolveErr.Expr)
+	assert.EqualError(t, err, "config: resolve 'db.url': ${DB_HOST}: unresolved reference")
+
+	obj, _ = zjson.ParseToJsonObject(`{"a": "${b}", "b": "x${c}", "c": "${a}"}`)
+	_, err = r.Resolve(obj)
+	assert.ErrorIs(t, err, ErrCycle)
+	assert.ErrorContains(t, err, "a -> b -> c -> a")
+
+	obj, _ = zjson.ParseToJsonObject(`{"a": "${b"}`)
+	_, err = r.Resolve(obj)
+	assert.EqualError(t, err, "config: resolve 'a': ${b: missing closing '}'")
+}
+
+// 测试 Loader 加载后展开，来源记录保持不变
+func TestLoader_WithResolver(t *testing.T) {
+	t.Setenv("ZJSON_TEST_HOST", "10.0.0.1")
+	snap, err := New(
+		Defaults(`{"server": {"host": "${ZJSON_TEST_HOST}", "port": 80}, "addr": "${server.host}:${server.port}"}`),
+	).WithResolver(NewResolver()).Load()
+	assert.Nil(t, err)
+	addr, _ := snap.Get("addr")
+	assert.Equal(t, "10.0.0.1:80", addr)
+	source, _ := snap.Source("addr")
+	assert.Equal(t, "defaults", source)
+
+	_, err = New(Defaults(`{"a": "${ZJSON_TEST_MISSING}"}`)).WithResolver(NewResolver()).Load()
+	assert.True(t, strings.HasPrefix(err.Error(), "config: resolve 'a'"))
+}
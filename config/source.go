@@ -0,0 +1,162 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/codinglz/zjson"
+)
+
+// Source 是一个配置来源，Name 用于记录每个值来自哪个来源
+type Source interface {
+	Name() string
+	Load() (*zjson.JsonObject, error)
+}
+
+type fileSource struct {
+	path     string
+	optional bool
+}
+
+// File 读取 JSON 文件，文件不存在时返回错误
+func File(path string) Source {
+	return &fileSource{path: path}
+}
+
+// OptionalFile 与 File 相同，但文件不存在时视为空配置，适用于环境相关的覆盖文件
+func OptionalFile(path string) Source {
+	return &fileSource{path: path, optional: true}
+}
+
+func (s *fileSource) Name() string {
+	return "file:" + s.path
+}
+
+func (s *fileSource) Load() (*zjson.JsonObject, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if s.optional && errors.Is(err, fs.ErrNotExist) {
+			return zjson.NewJsonObject(), nil
+		}
+		return nil, err
+	}
+	return zjson.ParseToJsonObject(data)
+}
+
+type envSource struct {
+	prefix  string
+	environ func() []string
+}
+
+// Env 读取以 prefix 加双下划线开头的环境变量，其余部分按双下划线分段并转为小写作为路径，
+// 如 APP__SERVER__PORT 对应 server.port。值是 JSON 对象或数组时按 JSON 解析，其余都作为字符串，
+// 由 Snapshot.Bind 和类型化的读取方法按需转换。
+func Env(prefix string) Source {
+	return &envSource{prefix: prefix, environ: os.Environ}
+}
+
+func (s *envSource) Name() string {
+	return "env"
+}
+
+func (s *envSource) Load() (*zjson.JsonObject, error) {
+	root := make(map[string]any)
+	for _, kv := range s.environ() {
+		name, value, _ := strings.Cut(kv, "=")
+		rest, ok := strings.CutPrefix(name, s.prefix+"__")
+		if !ok || rest == "" {
+			continue
+		}
+		path := strings.Split(strings.ToLower(rest), "__")
+		if err := setPath(root, path, parseValue(value)); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return zjson.ParseToJsonObject(root)
+}
+
+type flagSource struct {
+	flags *flag.FlagSet
+}
+
+// Flags 读取命令行中显式设置的参数，参数名中的点号表示嵌套，如 -server.port=8080，
+// 未设置的参数不会覆盖其他来源，取值规则与 Env 相同
+func Flags(flags *flag.FlagSet) Source {
+	return &flagSource{flags: flags}
+}
+
+func (s *flagSource) Name() string {
+	return "flags"
+}
+
+func (s *flagSource) Load() (*zjson.JsonObject, error) {
+	root := make(map[string]any)
+	var err error
+	s.flags.Visit(func(f *flag.Flag) {
+		if err == nil {
+			if setErr := setPath(root, strings.Split(f.Name, "."), parseValue(f.Value.String())); setErr != nil {
+				err = fmt.Errorf("-%s: %w", f.Name, setErr)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return zjson.ParseToJsonObject(root)
+}
+
+type defaultsSource struct {
+	value any
+}
+
+// Defaults 使用结构体、map 或 JSON 文本作为默认值，通常作为优先级最低的来源
+func Defaults(value any) Source {
+	return &defaultsSource{value: value}
+}
+
+func (s *defaultsSource) Name() string {
+	return "defaults"
+}
+
+func (s *defaultsSource) Load() (*zjson.JsonObject, error) {
+	return zjson.ParseToJsonObject(s.value)
+}
+
+// parseValue 只解析对象和数组，数字和布尔值等标量保留原始字符串，避免 12345 这样的密码变成数字
+func parseValue(raw string) any {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return raw
+	}
+	var val any
+	if err := json.Unmarshal([]byte(trimmed), &val); err != nil {
+		return raw
+	}
+	return val
+}
+
+func setPath(root map[string]any, path []string, value any) error {
+	node := root
+	for i, segment := range path[:len(path)-1] {
+		child, ok := node[segment].(map[string]any)
+		if !ok {
+			if _, exist := node[segment]; exist {
+				return fmt.Errorf("'%s' is already set to a non-object value", strings.Join(path[:i+1], "."))
+			}
+			child = make(map[string]any)
+			node[segment] = child
+		}
+		node = child
+	}
+	last := path[len(path)-1]
+	if _, ok := node[last].(map[string]any); ok {
+		return fmt.Errorf("'%s' is already set to an object", strings.Join(path, "."))
+	}
+	node[last] = value
+	return nil
+}
@@ -0,0 +1,294 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const defaultPollInterval = time.Second
+
+type WatchOptions struct {
+	// Interval 为检查文件变化的间隔，默认 1 秒
+	Interval time.Duration
+	// Validate 校验新加载的配置，返回错误时保留当前配置
+	Validate func(*Snapshot) error
+	// OnError 接收后台重新加载时的错误，为 nil 时忽略
+	OnError func(error)
+}
+
+// Change 描述一次生效的配置变更，Paths 为新增、删除或取值变化的叶子路径，按字典序排列
+type Change struct {
+	Old   *Snapshot
+	New   *Snapshot
+	Paths []string
+}
+
+// Watcher 定期检查 File 和 OptionalFile 来源的文件，文件变化时重新加载全部来源，
+// 校验通过且内容有变化时原子地替换当前配置并通知订阅者
+type Watcher struct {
+	loader  *Loader
+	opts    WatchOptions
+	current atomic.Pointer[Snapshot]
+
+	reloadMu sync.Mutex
+	stamps   map[string]fileStamp
+
+	mu          sync.Mutex
+	subscribers map[int]func(Change)
+	nextID      int
+	// queue 为等待通知的变更，由 notifyLoop 按生效顺序逐个发送
+	queue []Change
+	wake  chan struct{}
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+type fileStamp struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+// Watch 完成首次加载和校验后开始在后台检查文件，首次加载失败时直接返回错误
+func (l *Loader) Watch(opts WatchOptions) (*Watcher, error) {
+	if opts.Interval <= 0 {
+		opts.Interval = defaultPollInterval
+	}
+	w := &Watcher{
+		loader:      l,
+		opts:        opts,
+		subscribers: make(map[int]func(Change)),
+		wake:        make(chan struct{}, 1),
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	w.stamps = w.statFiles()
+	snap, err := w.load()
+	if err != nil {
+		return nil, err
+	}
+	w.current.Store(snap)
+	go w.poll()
+	go w.notifyLoop()
+	return w, nil
+}
+
+// Current 返回当前生效的配置，可以在任意 goroutine 中调用
+func (w *Watcher) Current() *Snapshot {
+	return w.current.Load()
+}
+
+// Subscribe 注册变更通知，返回的函数用于取消订阅。
+// 回调在单独的后台 goroutine 中按变更生效的顺序和注册顺序依次调用，调用时不持有内部锁，回调中可以调用 Reload 和 Close
+func (w *Watcher) Subscribe(fn func(Change)) func() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	id := w.nextID
+	w.nextID++
+	w.subscribers[id] = fn
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.subscribers, id)
+	}
+}
+
+// Reload 立即重新加载，不论文件是否变化，校验失败时返回错误并保留当前配置。
+// 返回时新配置已经生效，订阅者的通知异步发送
+func (w *Watcher) Reload() error {
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+	w.stamps = w.statFiles()
+	return w.reload()
+}
+
+// Close 停止后台检查并等待其退出，尚未发送的通知被丢弃，不等待正在执行的回调
+func (w *Watcher) Close() {
+	w.stopOnce.Do(func() {
+		close(w.stop)
+	})
+	<-w.done
+}
+
+func (w *Watcher) poll() {
+	defer close(w.done)
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			if err := w.checkFiles(); err != nil && w.opts.OnError != nil {
+				w.opts.OnError(err)
+			}
+		}
+	}
+}
+
+func (w *Watcher) checkFiles() error {
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+	// 先记录文件状态再加载，加载期间发生的修改会在下一轮被发现
+	stamps := w.statFiles()
+	if stampsEqual(stamps, w.stamps) {
+		return nil
+	}
+	w.stamps = stamps
+	return w.reload()
+}
+
+// reload 调用方需持有 reloadMu，变更在锁内生效并入队，保证通知顺序与生效顺序一致
+func (w *Watcher) reload() error {
+	snap, err := w.load()
+	if err != nil {
+		return err
+	}
+	old := w.current.Load()
+	paths := changedPaths(old, snap)
+	if len(paths) == 0 {
+		return nil
+	}
+	w.current.Store(snap)
+
+	w.mu.Lock()
+	w.queue = append(w.queue, Change{Old: old, New: snap, Paths: paths})
+	w.mu.Unlock()
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (w *Watcher) notifyLoop() {
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-w.wake:
+		}
+		for {
+			change, subscribers, ok := w.nextChange()
+			if !ok {
+				break
+			}
+			for _, fn := range subscribers {
+				select {
+				case <-w.stop:
+					return
+				default:
+				}
+				fn(change)
+			}
+		}
+	}
+}
+
+// nextChange 取出下一个变更以及此时的订阅者
+func (w *Watcher) nextChange() (Change, []func(Change), bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.queue) == 0 {
+		return Change{}, nil, false
+	}
+	change := w.queue[0]
+	w.queue = w.queue[1:]
+
+	ids := make([]int, 0, len(w.subscribers))
+	for id := range w.subscribers {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	subscribers := make([]func(Change), len(ids))
+	for i, id := range ids {
+		subscribers[i] = w.subscribers[id]
+	}
+	return change, subscribers, true
+}
+
+func (w *Watcher) load() (*Snapshot, error) {
+	snap, err := w.loader.Load()
+	if err != nil {
+		return nil, err
+	}
+	if w.opts.Validate != nil {
+		if err := w.opts.Validate(snap); err != nil {
+			return nil, fmt.Errorf("config: validation failed: %w", err)
+		}
+	}
+	return snap, nil
+}
+
+func (w *Watcher) statFiles() map[string]fileStamp {
+	stamps := make(map[string]fileStamp)
+	for _, source := range w.loader.sources {
+		file, ok := source.(*fileSource)
+		if !ok {
+			continue
+		}
+		info, err := os.Stat(file.path)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				stamps[file.path] = fileStamp{}
+			}
+			continue
+		}
+		stamps[file.path] = fileStamp{exists: true, size: info.Size(), modTime: info.ModTime()}
+	}
+	return stamps
+}
+
+func stampsEqual(a, b map[string]fileStamp) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, stamp := range a {
+		other, ok := b[path]
+		if !ok || stamp.exists != other.exists || stamp.size != other.size || !stamp.modTime.Equal(other.modTime) {
+			return false
+		}
+	}
+	return true
+}
+
+// changedPaths 比较两次加载结果的叶子值
+func changedPaths(old, new *Snapshot) []string {
+	oldLeaves, newLeaves := leafValues(old), leafValues(new)
+	var paths []string
+	for path, val := range newLeaves {
+		if oldVal, ok := oldLeaves[path]; !ok || !bytes.Equal(oldVal, val) {
+			paths = append(paths, path)
+		}
+	}
+	for path := range oldLeaves {
+		if _, ok := newLeaves[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func leafValues(snap *Snapshot) map[string][]byte {
+	res := make(map[string][]byte)
+	if snap == nil {
+		return res
+	}
+	for _, path := range leafPaths(snap.obj, "") {
+		val, _ := snap.Get(path)
+		raw, _ := json.Marshal(val)
+		res[path] = raw
+	}
+	return res
+}
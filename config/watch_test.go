@@ -0,0 +1,122 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/codinglz/zjson"
+	"github.com/stretchr/testify/assert"
+)
+
+func waitFor[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+	select {
+	case val := <-ch:
+		return val
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for watcher")
+	}
+	var zero T
+	return zero
+}
+
+// replaceFile 先写临时文件再重命名，避免轮询读到写了一半的文件
+func replaceFile(t *testing.T, path, content string) {
+	t.Helper()
+	tmp := path + ".tmp"
+	assert.Nil(t, os.WriteFile(tmp, []byte(content), 0o644))
+	assert.Nil(t, os.Rename(tmp, path))
+}
+
+// 测试文件变化后重新加载、校验并通知订阅者
+func TestWatcher_Reload(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "app.json", `{"server": {"port": 80}, "name": "a"}`)
+	errs := make(chan error, 10)
+	watcher, err := New(File(path)).Watch(WatchOptions{
+		Interval: 10 * time.Millisecond,
+		Validate: func(snap *Snapshot) error {
+			port, _ := snap.Get("server.port")
+			if port == 0.0 {
+				return errors.New("server.port is required")
+			}
+			return nil
+		},
+		OnError: func(err error) {
+			errs <- err
+		},
+	})
+	assert.Nil(t, err)
+	defer watcher.Close()
+
+	changes := make(chan Change, 10)
+	unsubscribe := watcher.Subscribe(func(change Change) {
+		changes <- change
+	})
+
+	replaceFile(t, path, `{"server": {"port": 81}, "name": "a", "debug": true}`)
+	change := waitFor(t, changes)
+	assert.Equal(t, []string{"debug", "server.port"}, change.Paths)
+	port, _ := change.Old.Get("server.port")
+	assert.Equal(t, 80.0, port)
+	assert.Same(t, change.New, watcher.Current())
+	assert.Equal(t, 81, watcher.Current().Object().GetJsonObjectIgnoreError("server").GetIntIgnoreError("port"))
+
+	// 解析失败和校验失败时保留当前配置
+	replaceFile(t, path, `{"server": `)
+	assert.ErrorIs(t, waitFor(t, errs), zjson.ErrParse)
+	replaceFile(t, path, `{"server": {"port": 0}, "name": "a", "debug": true}`)
+	assert.ErrorContains(t, waitFor(t, errs), "config: validation failed: server.port is required")
+	assert.Same(t, change.New, watcher.Current())
+
+	// 内容没有变化时不通知
+	replaceFile(t, path, `{"server": {"port": 81}, "name": "a", "debug": true}`)
+	assert.Nil(t, watcher.Reload())
+	unsubscribe()
+	replaceFile(t, path, `{"server": {"port": 82}}`)
+	assert.Nil(t, watcher.Reload())
+	port, _ = watcher.Current().Get("server.port")
+	assert.Equal(t, 82.0, port)
+	assert.Empty(t, changes)
+}
+
+// 测试首次加载失败时直接返回错误
+func TestWatcher_InitialLoad(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "app.json", `{}`)
+	_, err := New(File(path)).Watch(WatchOptions{
+		Validate: func(*Snapshot) error {
+			return errors.New("empty config")
+		},
+	})
+	assert.EqualError(t, err, "config: validation failed: empty config")
+}
+
+// 测试回调中可以调用 Reload 和 Close，通知按生效顺序发送
+func TestWatcher_ReentrantSubscriber(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "app.json", `{"version": 1}`)
+	watcher, err := New(File(path)).Watch(WatchOptions{Interval: time.Hour})
+	assert.Nil(t, err)
+
+	versions := make(chan any, 10)
+	closed := make(chan struct{})
+	watcher.Subscribe(func(change Change) {
+		version, _ := change.New.Get("version")
+		versions <- version
+		if version == 2.0 {
+			replaceFile(t, path, `{"version": 3}`)
+			assert.Nil(t, watcher.Reload())
+			return
+		}
+		watcher.Close()
+		close(closed)
+	})
+
+	replaceFile(t, path, `{"version": 2}`)
+	assert.Nil(t, watcher.Reload())
+	assert.Equal(t, 2.0, waitFor(t, versions))
+	assert.Equal(t, 3.0, waitFor(t, versions))
+	waitFor(t, closed)
+	version, _ := watcher.Current().Get("version")
+	assert.Equal(t, 3.0, version)
+}
@@ -0,0 +1,137 @@
+package zjson
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type countingParser struct {
+	defaultParser
+	mu      sync.Mutex
+	encoded int
+}
+
+func (p *countingParser) AnyToJsonString(v any) ([]byte, error) {
+	p.mu.Lock()
+	p.encoded++
+	p.mu.Unlock()
+	return p.defaultParser.AnyToJsonString(v)
+}
+
+// 测试配置中的解析器只作用于由该配置创建的容器及其子节点
+func TestConfig_Parser(t *testing.T) {
+	parser := &countingParser{}
+	cfg := Config{Parser: parser}
+
+	obj, err := cfg.ParseToJsonObject(`{"user": {"name": "Tom"}, "tags": [{"id": 1}]}`)
+	assert.Nil(t, err)
+	user := obj.GetJsonObjectIgnoreError("user")
+	assert.Equal(t, `{"name":"Tom"}`, user.ToJsonStr())
+	assert.Equal(t, 1, parser.encoded)
+
+	tag, err := obj.GetJsonArrayIgnoreError("tags").GetJsonObject(0)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"id":1}`, tag.ToJsonStr())
+	assert.Equal(t, 2, parser.encoded)
+
+	other, _ := ParseToJsonObject(`{"a": 1}`)
+	other.ToJsonStr()
+	assert.Equal(t, 2, parser.encoded)
+	assert.Equal(t, Config{}, other.Config())
+	assert.Equal(t, parser, obj.Config().Parser)
+}
+
+// 测试数字模式
+func TestConfig_Numbers(t *testing.T) {
+	obj, err := Config{Numbers: NumberJSON}.ParseToJsonObject(`{"id": 9007199254740993, "price": 1.10}`)
+	assert.Nil(t, err)
+	assert.Equal(t, json.Number("9007199254740993"), obj.Get("id"))
+	assert.Equal(t, int64(9007199254740993), obj.GetInt64IgnoreError("id"))
+	assert.Equal(t, `{"id":9007199254740993,"price":1.10}`, obj.ToJsonStr())
+
+	arr, err := Config{Numbers: NumberInt64}.ParseToArray(`[1, 1.5, 1e2, 99999999999999999999]`)
+	assert.Nil(t, err)
+	assert.Equal(t, []any{int64(1), 1.5, 100.0, 1e20}, arr.snapshot())
+
+	_, err = Config{Numbers: NumberJSON}.ParseToArray(`{"a": 1}`)
+	assert.ErrorIs(t, err, ErrParse)
+}
+
+// 测试保持键的插入顺序
+func TestConfig_KeyOrder(t *testing.T) {
+	cfg := Config{KeyOrder: KeysInsertion}
+	obj, err := cfg.ParseToJsonObject(`{"b": 1, "a": {"z": 1, "y": 2}, "c": [{"k2": 1, "k1": 2}]}`)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"b":1,"a":{"z":1,"y":2},"c":[{"k2":1,"k1":2}]}`, obj.ToJsonStr())
+
+	obj.Put("0", true)
+	obj.Put("b", 2)
+	obj.Remove("a")
+	var keys []string
+	for key := range obj.Keys() {
+		keys = append(keys, key)
+	}
+	assert.Equal(t, []string{"b", "c", "0"}, keys)
+	assert.Equal(t, `{"b":2,"c":[{"k2":1,"k1":2}],"0":true}`, obj.DeepClone().ToJsonStr())
+
+	created := cfg.NewJsonObject()
+	created.Put("y", 1)
+	created.Put("x", 2)
+	assert.Equal(t, `{"y":1,"x":2}`, created.ToJsonStr())
+}
+
+// 测试有序对象中写入的原生 map 和切片通过子视图读写后不会丢失键
+func TestConfig_KeyOrderChildView(t *testing.T) {
+	obj := Config{KeyOrder: KeysInsertion}.NewJsonObject()
+	obj.Put("a", map[string]any{"b": 1, "a": 2})
+	obj.Put("list", []any{map[string]any{"k": 1}})
+
+	child := obj.GetJsonObjectIgnoreError("a")
+	assert.Equal(t, `{"a":{"a":2,"b":1},"list":[{"k":1}]}`, obj.ToJsonStr())
+	child.Put("0", 3)
+	assert.Equal(t, `{"a":2,"b":1,"0":3}`, child.ToJsonStr())
+
+	item := obj.GetJsonArrayIgnoreError("list").GetJsonObjectIgnoreError(0)
+	item.Put("j", 2)
+	assert.Equal(t, `{"a":{"a":2,"b":1,"0":3},"list":[{"k":1,"j":2}]}`, obj.ToJsonStr())
+
+	parsed, err := Config{KeyOrder: KeysInsertion}.ParseToJsonObject(obj.ToJsonStr())
+	assert.Nil(t, err)
+	assert.Equal(t, obj.ToJsonStr(), parsed.ToJsonStr())
+}
+
+// 测试配置中的转换策略传递给子节点，容器单独设置的策略优先
+func TestConfig_CoercionPolicy(t *testing.T) {
+	obj, err := Config{CoercionPolicy: CoercionStrict}.ParseToJsonObject(`{"user": {"age": "18"}}`)
+	assert.Nil(t, err)
+	user := obj.GetJsonObjectIgnoreError("user")
+	_, err = user.GetInt("age")
+	assert.ErrorIs(t, err, ErrTypeMismatch)
+	assert.Equal(t, CoercionStrict, user.CoercionPolicy())
+
+	user.SetCoercionPolicy(CoercionDefault)
+	assert.Equal(t, 18, user.GetIntIgnoreError("age"))
+}
+
+// 测试解析过程中并发调用 SetParser 不会产生数据竞争
+func TestConfig_SetParserConcurrent(t *testing.T) {
+	defer SetParser(&defaultParser{})
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			SetParser(&defaultParser{})
+		}()
+		go func() {
+			defer wg.Done()
+			obj, err := ParseToJsonObject(`{"a": 1}`)
+			assert.Nil(t, err)
+			assert.Equal(t, `{"a":1}`, obj.ToJsonStr())
+		}()
+	}
+	wg.Wait()
+}
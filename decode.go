@@ -0,0 +1,469 @@
+package zjson
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// decoder 是内置的 JSON 解析器，解析过程中记录当前路径，出错时可以精确定位
+type decoder struct {
+	data []byte
+	pos  int
+	path []string
+	cfg  *Config
+	// depth 和 nodes 用于检查 Limits
+	depth int
+	nodes int
+	// lazyDepth 大于 0 时，层级不低于它的嵌套对象和数组保留为 lazyNode
+	lazyDepth int
+}
+
+// newDecoder 的 cfg 可以为 nil，此时解析结果与 encoding/json 一致
+func newDecoder(data []byte, cfg *Config) *decoder {
+	return &decoder{data: data, cfg: cfg}
+}
+
+// decode 解析完整的文档，值之后只允许出现空白字符
+func (d *decoder) decode() (any, error) {
+	d.skipSpace()
+	val, err := d.value()
+	if err != nil {
+		return nil, err
+	}
+	d.skipSpace()
+	if d.pos < len(d.data) {
+		return nil, d.syntaxError("invalid character %s after top-level value", quoteChar(d.data[d.pos]))
+	}
+	return val, nil
+}
+
+func (d *decoder) value() (any, error) {
+	if d.pos >= len(d.data) {
+		return nil, d.unexpectedEOF()
+	}
+	d.nodes++
+	if max := d.cfg.limits().MaxNodes; max > 0 && d.nodes > max {
+		return nil, d.limitError("MaxNodes", max)
+	}
+	switch c := d.data[d.pos]; {
+	case (c == '{' || c == '[') && d.lazyDepth > 0 && d.depth >= d.lazyDepth:
+		return d.lazyValue()
+	case c == '{':
+		return d.object()
+	case c == '[':
+		return d.array()
+	case c == '"':
+		return d.string()
+	case c == '-' || isDigit(c):
+		return d.number()
+	case c == 't':
+		return d.literal("true", true)
+	case c == 'f':
+		return d.literal("false", false)
+	case c == 'n':
+		return d.literal("null", nil)
+	default:
+		return nil, d.syntaxError("invalid character %s looking for beginning of value", quoteChar(c))
+	}
+}
+
+// object 在 KeysInsertion 模式下直接返回记录了键顺序的 *JsonObject，否则返回原生 map
+func (d *decoder) object() (any, error) {
+	if err := d.enter(); err != nil {
+		return nil, err
+	}
+	defer d.leave()
+	d.pos++
+	obj := make(map[string]any)
+	var (
+		keys      []string
+		collected map[string]bool
+	)
+	ordered := d.cfg.ordered()
+	result := func() any {
+		if ordered {
+			return &JsonObject{data: obj, cfg: d.cfg, keys: keys}
+		}
+		return obj
+	}
+	d.skipSpace()
+	if d.pos < len(d.data) && d.data[d.pos] == '}' {
+		d.pos++
+		return result(), nil
+	}
+
+	for {
+		d.skipSpace()
+		if d.pos >= len(d.data) {
+			return nil, d.unexpectedEOF()
+		}
+		if d.data[d.pos] != '"' {
+			return nil, d.syntaxError("invalid character %s looking for beginning of object key string", quoteChar(d.data[d.pos]))
+		}
+		keyOffset := d.pos
+		key, err := d.string()
+		if err != nil {
+			return nil, err
+		}
+		_, dup := obj[key]
+		if dup {
+			if err := d.duplicateKey(key, keyOffset); err != nil {
+				return nil, err
+			}
+		}
+
+		d.skipSpace()
+		if d.pos >= len(d.data) {
+			return nil, d.unexpectedEOF()
+		}
+		if d.data[d.pos] != ':' {
+			return nil, d.syntaxError("invalid character %s after object key", quoteChar(d.data[d.pos]))
+		}
+		d.pos++
+		d.skipSpace()
+
+		d.path = append(d.path, key)
+		val, err := d.value()
+		if err != nil {
+			return nil, err
+		}
+		d.path = d.path[:len(d.path)-1]
+		switch {
+		case !dup:
+			if max := d.cfg.limits().MaxObjectKeys; max > 0 && len(obj) >= max {
+				return nil, d.limitError("MaxObjectKeys", max)
+			}
+			if ordered {
+				keys = append(keys, key)
+			}
+			obj[key] = val
+		case d.cfg.duplicateKeys() == DuplicateFirstWins:
+		case d.cfg.duplicateKeys() == DuplicateCollect:
+			// collected 记录已经合并为数组的键，避免与原本就是数组的值混淆
+			if collected[key] {
+				obj[key] = append(obj[key].([]any), val)
+			} else {
+				if collected == nil {
+					collected = make(map[string]bool)
+				}
+				collected[key] = true
+				obj[key] = []any{obj[key], val}
+			}
+		default:
+			obj[key] = val
+		}
+
+		d.skipSpace()
+		if d.pos >= len(d.data) {
+			return nil, d.unexpectedEOF()
+		}
+		switch d.data[d.pos] {
+		case ',':
+			d.pos++
+		case '}':
+			d.pos++
+			return result(), nil
+		default:
+			return nil, d.syntaxError("invalid character %s after object key:value pair", quoteChar(d.data[d.pos]))
+		}
+	}
+}
+
+func (d *decoder) array() (any, error) {
+	if err := d.enter(); err != nil {
+		return nil, err
+	}
+	defer d.leave()
+	d.pos++
+	arr := make([]any, 0)
+	d.skipSpace()
+	if d.pos < len(d.data) && d.data[d.pos] == ']' {
+		d.pos++
+		return arr, nil
+	}
+
+	for {
+		if max := d.cfg.limits().MaxArrayLength; max > 0 && len(arr) >= max {
+			return nil, d.limitError("MaxArrayLength", max)
+		}
+		d.skipSpace()
+		d.path = append(d.path, strconv.Itoa(len(arr)))
+		val, err := d.value()
+		if err != nil {
+			return nil, err
+		}
+		d.path = d.path[:len(d.path)-1]
+		arr = append(arr, val)
+
+		d.skipSpace()
+		if d.pos >= len(d.data) {
+			return nil, d.unexpectedEOF()
+		}
+		switch d.data[d.pos] {
+		case ',':
+			d.pos++
+		case ']':
+			d.pos++
+			return arr, nil
+		default:
+			return nil, d.syntaxError("invalid character %s after array element", quoteChar(d.data[d.pos]))
+		}
+	}
+}
+
+func (d *decoder) string() (string, error) {
+	d.pos++
+	start := d.pos
+	var sb strings.Builder
+	for {
+		if d.pos >= len(d.data) {
+			return "", d.unexpectedEOF()
+		}
+		c := d.data[d.pos]
+		switch {
+		case c == '"':
+			sb.Write(d.data[start:d.pos])
+			if max := d.cfg.limits().MaxStringLength; max > 0 && sb.Len() > max {
+				return "", d.limitError("MaxStringLength", max)
+			}
+			d.pos++
+			if d.cfg.normalizeNFC() {
+				return norm.NFC.String(sb.String()), nil
+			}
+			return sb.String(), nil
+		case c == '\\':
+			sb.Write(d.data[start:d.pos])
+			if err := d.escape(&sb); err != nil {
+				return "", err
+			}
+			start = d.pos
+		case c < 0x20:
+			return "", d.syntaxError("invalid character %s in string literal", quoteChar(c))
+		case c < utf8.RuneSelf:
+			d.pos++
+		default:
+			r, size := utf8.DecodeRune(d.data[d.pos:])
+			if r == utf8.RuneError && size == 1 {
+				sb.Write(d.data[start:d.pos])
+				if err := d.invalidByte(&sb, c); err != nil {
+					return "", err
+				}
+				d.pos++
+				start = d.pos
+				continue
+			}
+			d.pos += size
+		}
+	}
+}
+
+func (d *decoder) escape(sb *strings.Builder) error {
+	escStart := d.pos
+	d.pos++
+	if d.pos >= len(d.data) {
+		return d.unexpectedEOF()
+	}
+	c := d.data[d.pos]
+	d.pos++
+	switch c {
+	case '"', '\\', '/':
+		sb.WriteByte(c)
+	case 'b':
+		sb.WriteByte('\b')
+	case 'f':
+		sb.WriteByte('\f')
+	case 'n':
+		sb.WriteByte('\n')
+	case 'r':
+		sb.WriteByte('\r')
+	case 't':
+		sb.WriteByte('\t')
+	case 'u':
+		r, err := d.hex4()
+		if err != nil {
+			return err
+		}
+		if utf16.IsSurrogate(r) {
+			// 尝试与紧随其后的 \uXXXX 组成代理对，失败时按无效字符处理
+			if d.pos+1 < len(d.data) && d.data[d.pos] == '\\' && d.data[d.pos+1] == 'u' {
+				saved := d.pos
+				d.pos += 2
+				r2, err := d.hex4()
+				if err != nil {
+					return err
+				}
+				if pair := utf16.DecodeRune(r, r2); pair != utf8.RuneError {
+					sb.WriteRune(pair)
+					return nil
+				}
+				d.pos = saved
+			}
+			return d.loneSurrogate(sb, r, escStart)
+		}
+		sb.WriteRune(r)
+	default:
+		d.pos--
+		return d.syntaxError("invalid character %s in string escape code", quoteChar(c))
+	}
+	return nil
+}
+
+func (d *decoder) hex4() (rune, error) {
+	if d.pos+4 > len(d.data) {
+		d.pos = len(d.data)
+		return 0, d.unexpectedEOF()
+	}
+	var r rune
+	for i := 0; i < 4; i++ {
+		c := d.data[d.pos]
+		var v byte
+		switch {
+		case isDigit(c):
+			v = c - '0'
+		case 'a' <= c && c <= 'f':
+			v = c - 'a' + 10
+		case 'A' <= c && c <= 'F':
+			v = c - 'A' + 10
+		default:
+			return 0, d.syntaxError("invalid character %s in \\u hexadecimal character escape", quoteChar(c))
+		}
+		r = r<<4 | rune(v)
+		d.pos++
+	}
+	return r, nil
+}
+
+func (d *decoder) number() (any, error) {
+	start := d.pos
+	if d.data[d.pos] == '-' {
+		d.pos++
+	}
+	if d.pos >= len(d.data) {
+		return nil, d.unexpectedEOF()
+	}
+
+	switch c := d.data[d.pos]; {
+	case c == '0':
+		d.pos++
+	case '1' <= c && c <= '9':
+		d.skipDigits()
+	default:
+		return nil, d.syntaxError("invalid character %s in numeric literal", quoteChar(c))
+	}
+
+	if d.pos < len(d.data) && d.data[d.pos] == '.' {
+		d.pos++
+		if err := d.requireDigit("after decimal point in numeric literal"); err != nil {
+			return nil, err
+		}
+		d.skipDigits()
+	}
+	if d.pos < len(d.data) && (d.data[d.pos] == 'e' || d.data[d.pos] == 'E') {
+		d.pos++
+		if d.pos < len(d.data) && (d.data[d.pos] == '+' || d.data[d.pos] == '-') {
+			d.pos++
+		}
+		if err := d.requireDigit("in exponent of numeric literal"); err != nil {
+			return nil, err
+		}
+		d.skipDigits()
+	}
+
+	literal := string(d.data[start:d.pos])
+	if d.cfg != nil {
+		switch d.cfg.Numbers {
+		case NumberJSON:
+			return json.Number(literal), nil
+		case NumberInt64:
+			if i, err := strconv.ParseInt(literal, 10, 64); err == nil {
+				return i, nil
+			}
+		}
+	}
+	number, err := strconv.ParseFloat(literal, 64)
+	if err != nil {
+		d.pos = start
+		return nil, d.syntaxError("number %s out of range", literal)
+	}
+	return number, nil
+}
+
+func (d *decoder) enter() error {
+	d.depth++
+	if max := d.cfg.limits().MaxDepth; max > 0 && d.depth > max {
+		return d.limitError("MaxDepth", max)
+	}
+	return nil
+}
+
+func (d *decoder) leave() {
+	d.depth--
+}
+
+func (d *decoder) requireDigit(context string) error {
+	if d.pos >= len(d.data) {
+		return d.unexpectedEOF()
+	}
+	if !isDigit(d.data[d.pos]) {
+		return d.syntaxError("invalid character %s %s", quoteChar(d.data[d.pos]), context)
+	}
+	return nil
+}
+
+func (d *decoder) skipDigits() {
+	for d.pos < len(d.data) && isDigit(d.data[d.pos]) {
+		d.pos++
+	}
+}
+
+func (d *decoder) literal(text string, val any) (any, error) {
+	for i := 0; i < len(text); i++ {
+		if d.pos >= len(d.data) {
+			return nil, d.unexpectedEOF()
+		}
+		if d.data[d.pos] != text[i] {
+			return nil, d.syntaxError("invalid character %s in literal %s (expecting %s)", quoteChar(d.data[d.pos]), text, quoteChar(text[i]))
+		}
+		d.pos++
+	}
+	return val, nil
+}
+
+func (d *decoder) skipSpace() {
+	for d.pos < len(d.data) {
+		switch d.data[d.pos] {
+		case ' ', '\t', '\n', '\r':
+			d.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (d *decoder) unexpectedEOF() error {
+	return d.syntaxError("unexpected end of JSON input")
+}
+
+func (d *decoder) currentPath() string {
+	return strings.Join(d.path, ".")
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func quoteChar(c byte) string {
+	if c == '\'' {
+		return `'\''`
+	}
+	if c == '"' {
+		return `'"'`
+	}
+	s := strconv.Quote(string(rune(c)))
+	return "'" + s[1:len(s)-1] + "'"
+}
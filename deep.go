@@ -0,0 +1,363 @@
+package zjson
+
+import (
+	"encoding/json"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+)
+
+type EqualOptions struct {
+	// NumericEquivalence 为 true 时 int、float64、json.Number 等按数值比较，否则要求类型一致
+	NumericEquivalence bool
+	// NilEqualsMissing 为 true 时值为 null 的键与不存在的键视为相等
+	NilEqualsMissing bool
+	// FloatTolerance 浮点数比较允许的绝对误差
+	FloatTolerance float64
+}
+
+func (jo *JsonObject) DeepClone() *JsonObject {
+	jo.mu.RLock()
+	defer jo.mu.RUnlock()
+	return &JsonObject{data: cloneMap(jo.data), cfg: jo.cfg, policy: jo.policy, keys: slices.Clone(jo.keys)}
+}
+
+func (ja *JsonArray) DeepClone() *JsonArray {
+	ja.mu.RLock()
+	defer ja.mu.RUnlock()
+	return &JsonArray{data: cloneSlice(ja.data), cfg: ja.cfg, policy: ja.policy}
+}
+
+func (jo *JsonObject) DeepEqual(other *JsonObject, opts ...EqualOptions) bool {
+	if jo == nil || other == nil {
+		return jo == other
+	}
+	return deepEqual(jo, other, firstEqualOptions(opts))
+}
+
+func (ja *JsonArray) DeepEqual(other *JsonArray, opts ...EqualOptions) bool {
+	if ja == nil || other == nil {
+		return ja == other
+	}
+	return deepEqual(ja, other, firstEqualOptions(opts))
+}
+
+func firstEqualOptions(opts []EqualOptions) EqualOptions {
+	if len(opts) > 0 {
+		return opts[0]
+	}
+	return EqualOptions{}
+}
+
+func cloneMap(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+	res := make(map[string]any, len(m))
+	for k, v := range m {
+		res[k] = cloneValue(v)
+	}
+	return res
+}
+
+func cloneSlice(s []any) []any {
+	if s == nil {
+		return nil
+	}
+	res := make([]any, len(s))
+	for i, v := range s {
+		res[i] = cloneValue(v)
+	}
+	return res
+}
+
+func cloneValue(val any) any {
+	switch v := val.(type) {
+	case nil, string, bool, float64, int, int64, json.Number:
+		return v
+	case map[string]any:
+		return cloneMap(v)
+	case []any:
+		return cloneSlice(v)
+	case *JsonObject:
+		return v.DeepClone()
+	case *JsonArray:
+		return v.DeepClone()
+	case *lazyNode:
+		v.mu.Lock()
+		defer v.mu.Unlock()
+		return &lazyNode{raw: v.raw, cfg: v.cfg, depth: v.depth, path: v.path, val: cloneValue(v.val), err: v.err}
+	case json.RawMessage:
+		return append(json.RawMessage(nil), v...)
+	case []byte:
+		return append([]byte(nil), v...)
+	}
+	if obj, ok := copyObjectValue(val); ok {
+		return obj
+	} else if arr, ok := copyArrayValue(val); ok {
+		return arr
+	}
+	return cloneReflect(reflect.ValueOf(val)).Interface()
+}
+
+// copyObjectValue 处理以值形式保存的 JsonObject，接口内保存的是独立副本，没有其他持有者会对其加锁
+func copyObjectValue(val any) (*JsonObject, bool) {
+	switch v := val.(type) {
+	case JsonObject:
+		return &JsonObject{data: cloneMap(v.data)}, true
+	}
+	return nil, false
+}
+
+func copyArrayValue(val any) (*JsonArray, bool) {
+	switch v := val.(type) {
+	case JsonArray:
+		return &JsonArray{data: cloneSlice(v.data)}, true
+	}
+	return nil, false
+}
+
+func cloneReflect(rv reflect.Value) reflect.Value {
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.IsNil() {
+			return rv
+		}
+		res := reflect.MakeMapWithSize(rv.Type(), rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			res.SetMapIndex(iter.Key(), cloneElem(iter.Value(), rv.Type().Elem()))
+		}
+		return res
+	case reflect.Slice:
+		if rv.IsNil() {
+			return rv
+		}
+		res := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			res.Index(i).Set(cloneElem(rv.Index(i), rv.Type().Elem()))
+		}
+		return res
+	}
+	return rv
+}
+
+func cloneElem(rv reflect.Value, typ reflect.Type) reflect.Value {
+	if rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return reflect.Zero(typ)
+		}
+		return reflect.ValueOf(cloneValue(rv.Interface()))
+	}
+	return cloneReflect(rv)
+}
+
+func deepEqual(a, b any, opts EqualOptions) bool {
+	a, b = normalizeValue(a), normalizeValue(b)
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	if an, aok := toNumber(a); aok {
+		bn, bok := toNumber(b)
+		if !bok {
+			return false
+		}
+		if !opts.NumericEquivalence && reflect.TypeOf(a) != reflect.TypeOf(b) {
+			return false
+		}
+		return numberEqual(an, bn, opts.FloatTolerance)
+	}
+
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok {
+			return false
+		}
+		return mapEqual(av, bv, opts)
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !deepEqual(av[i], bv[i], opts) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func mapEqual(a, b map[string]any, opts EqualOptions) bool {
+	if !opts.NilEqualsMissing && len(a) != len(b) {
+		return false
+	}
+	for k, av := range a {
+		bv, exist := b[k]
+		if !exist {
+			if opts.NilEqualsMissing && normalizeValue(av) == nil {
+				continue
+			}
+			return false
+		}
+		if !deepEqual(av, bv, opts) {
+			return false
+		}
+	}
+	if opts.NilEqualsMissing {
+		for k, bv := range b {
+			if _, exist := a[k]; !exist && normalizeValue(bv) != nil {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// normalizeValue 将容器类型统一为 map[string]any 和 []any 的快照
+func normalizeValue(val any) any {
+	switch v := resolve(val).(type) {
+	case *JsonObject:
+		if v == nil {
+			return nil
+		}
+		return v.snapshot()
+	case *JsonArray:
+		if v == nil {
+			return nil
+		}
+		return v.snapshot()
+	case json.RawMessage:
+		var res any
+		if err := currentParser().JsonStringToAny(v, &res); err == nil {
+			return res
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// plainValue 将视图和延迟解析的节点递归还原为 map[string]any 和 []any，
+// 不含视图的原生容器原样返回，只有包含视图的容器会被复制
+func plainValue(val any) any {
+	res, _ := plain(val)
+	return res
+}
+
+// plain 返回还原后的值以及是否与原值不同
+func plain(val any) (any, bool) {
+	switch v := val.(type) {
+	case *lazyNode:
+		res, _ := plain(v.value())
+		return res, true
+	case *JsonObject:
+		if v == nil {
+			return nil, true
+		}
+		v.mu.RLock()
+		defer v.mu.RUnlock()
+		res := make(map[string]any, len(v.data))
+		for key, item := range v.data {
+			res[key], _ = plain(item)
+		}
+		return res, true
+	case *JsonArray:
+		if v == nil {
+			return nil, true
+		}
+		v.mu.RLock()
+		defer v.mu.RUnlock()
+		res := make([]any, len(v.data))
+		for i, item := range v.data {
+			res[i], _ = plain(item)
+		}
+		return res, true
+	case map[string]any:
+		var res map[string]any
+		for key, item := range v {
+			if converted, changed := plain(item); changed {
+				if res == nil {
+					res = maps.Clone(v)
+				}
+				res[key] = converted
+			}
+		}
+		if res == nil {
+			return v, false
+		}
+		return res, true
+	case []any:
+		var res []any
+		for i, item := range v {
+			if converted, changed := plain(item); changed {
+				if res == nil {
+					res = slices.Clone(v)
+				}
+				res[i] = converted
+			}
+		}
+		if res == nil {
+			return v, false
+		}
+		return res, true
+	}
+	return val, false
+}
+
+type number struct {
+	isInt bool
+	i     int64
+	f     float64
+}
+
+func toNumber(val any) (number, bool) {
+	switch v := val.(type) {
+	case int:
+		return number{isInt: true, i: int64(v), f: float64(v)}, true
+	case int8:
+		return number{isInt: true, i: int64(v), f: float64(v)}, true
+	case int16:
+		return number{isInt: true, i: int64(v), f: float64(v)}, true
+	case int32:
+		return number{isInt: true, i: int64(v), f: float64(v)}, true
+	case int64:
+		return number{isInt: true, i: v, f: float64(v)}, true
+	case uint:
+		return number{isInt: v <= math.MaxInt64, i: int64(v), f: float64(v)}, true
+	case uint8:
+		return number{isInt: true, i: int64(v), f: float64(v)}, true
+	case uint16:
+		return number{isInt: true, i: int64(v), f: float64(v)}, true
+	case uint32:
+		return number{isInt: true, i: int64(v), f: float64(v)}, true
+	case uint64:
+		return number{isInt: v <= math.MaxInt64, i: int64(v), f: float64(v)}, true
+	case float32:
+		return number{f: float64(v)}, true
+	case float64:
+		return number{f: v}, true
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return number{isInt: true, i: i, f: float64(i)}, true
+		}
+		if f, err := v.Float64(); err == nil {
+			return number{f: f}, true
+		}
+	}
+	return number{}, false
+}
+
+func numberEqual(a, b number, tolerance float64) bool {
+	if a.isInt && b.isInt {
+		return a.i == b.i
+	}
+	if tolerance > 0 {
+		return math.Abs(a.f-b.f) <= tolerance
+	}
+	return a.f == b.f
+}
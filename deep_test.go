@@ -0,0 +1,72 @@
+package zjson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJsonObject_DeepClone(t *testing.T) {
+	obj, err := ParseToJsonObject(`{"a":{"b":[1,{"c":2}]},"s":"x"}`)
+	assert.NoError(t, err)
+	nested := NewJsonObject()
+	nested.Put("tags", []string{"go"})
+	obj.Put("nested", nested)
+
+	clone := obj.DeepClone()
+	assert.True(t, obj.DeepEqual(clone))
+
+	// 修改副本不影响原对象
+	clone.GetJsonObjectIgnoreError("a").GetJsonArrayIgnoreError("b").GetJsonObjectIgnoreError(1).Put("c", 3)
+	clone.GetJsonObjectIgnoreError("nested").Get("tags").([]string)[0] = "rust"
+	assert.Equal(t, 2, obj.GetJsonObjectIgnoreError("a").GetJsonArrayIgnoreError("b").GetJsonObjectIgnoreError(1).GetIntIgnoreError("c"))
+	assert.Equal(t, []string{"go"}, nested.Get("tags"))
+	assert.False(t, obj.DeepEqual(clone))
+}
+
+func TestJsonArray_DeepClone(t *testing.T) {
+	arr, err := ParseToArrayLazy(`[{"id":1},[1,2]]`)
+	assert.NoError(t, err)
+
+	clone := arr.DeepClone()
+	clone.GetJsonObjectIgnoreError(0).Put("id", 2)
+	assert.Equal(t, 1, arr.GetJsonObjectIgnoreError(0).GetIntIgnoreError("id"))
+	assert.JSONEq(t, `[{"id":1},[1,2]]`, arr.ToJsonStr())
+}
+
+func TestJsonObject_DeepEqual(t *testing.T) {
+	x, y := 0.1, 0.2
+	a := NewJsonObject()
+	a.Put("n", 1)
+	a.Put("f", x+y)
+	a.Put("nested", map[string]any{"x": []any{1, "y"}})
+
+	b, err := ParseToJsonObject(`{"n":1,"f":0.3,"nested":{"x":[1,"y"]}}`)
+	assert.NoError(t, err)
+
+	// 默认要求类型一致，解析得到的数字是 float64
+	assert.False(t, a.DeepEqual(b))
+	assert.False(t, a.DeepEqual(b, EqualOptions{NumericEquivalence: true}))
+	assert.True(t, a.DeepEqual(b, EqualOptions{NumericEquivalence: true, FloatTolerance: 1e-9}))
+
+	c := NewJsonObject()
+	c.Put("n", json.Number("1"))
+	d := NewJsonObject()
+	d.Put("n", 1.0)
+	d.Put("missing", nil)
+	assert.False(t, c.DeepEqual(d, EqualOptions{NumericEquivalence: true}))
+	assert.True(t, c.DeepEqual(d, EqualOptions{NumericEquivalence: true, NilEqualsMissing: true}))
+	assert.True(t, d.DeepEqual(c, EqualOptions{NumericEquivalence: true, NilEqualsMissing: true}))
+
+	var nilObj *JsonObject
+	assert.False(t, a.DeepEqual(nilObj))
+}
+
+func TestParseToJsonObject_ValueCopy(t *testing.T) {
+	src := NewJsonObject()
+	src.Put("name", "Alice")
+	obj, err := ParseToJsonObject(src)
+	assert.NoError(t, err)
+	assert.Same(t, src, obj)
+}
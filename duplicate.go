@@ -0,0 +1,88 @@
+package zjson
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrDuplicateKey = errors.New("duplicate key")
+
+// DuplicateKeyPolicy 控制同一对象中出现重复键时的处理方式
+type DuplicateKeyPolicy int
+
+const (
+	// DuplicateLastWins 保留最后一次出现的值，与 encoding/json 一致
+	DuplicateLastWins DuplicateKeyPolicy = iota
+	// DuplicateError 遇到重复键时停止解析并返回 *DuplicateKeyError
+	DuplicateError
+	// DuplicateFirstWins 保留第一次出现的值
+	DuplicateFirstWins
+	// DuplicateCollect 将所有出现的值按顺序收集到数组中
+	DuplicateCollect
+)
+
+func (p DuplicateKeyPolicy) String() string {
+	switch p {
+	case DuplicateLastWins:
+		return "last-wins"
+	case DuplicateError:
+		return "error"
+	case DuplicateFirstWins:
+		return "first-wins"
+	case DuplicateCollect:
+		return "collect-into-array"
+	}
+	return "unknown"
+}
+
+// DuplicateKey 描述一次重复出现的键，位置指向重复出现时键的起始引号
+type DuplicateKey struct {
+	// Path 为重复键的完整路径，格式与 PathError.Path 相同
+	Path   string
+	Key    string
+	Offset int
+	Line   int
+	Column int
+}
+
+type DuplicateKeyError struct {
+	DuplicateKey
+}
+
+func (e *DuplicateKeyError) Error() string {
+	return fmt.Sprintf("%v: '%s' at line %d, column %d (offset %d)", ErrDuplicateKey, e.Path, e.Line, e.Column, e.Offset)
+}
+
+func (e *DuplicateKeyError) Unwrap() error {
+	return ErrDuplicateKey
+}
+
+func (c *Config) duplicateKeys() DuplicateKeyPolicy {
+	if c == nil {
+		return DuplicateLastWins
+	}
+	return c.DuplicateKeys
+}
+
+// duplicateKey 通知 OnDuplicateKey，DuplicateError 策略下返回错误
+func (d *decoder) duplicateKey(key string, offset int) error {
+	if d.cfg == nil {
+		return nil
+	}
+	line, column, _ := locate(d.data, offset)
+	dup := DuplicateKey{
+		Path:   strings.Join(append(d.path[:len(d.path):len(d.path)], key), "."),
+		Key:    key,
+		Offset: offset,
+		Line:   line,
+		Column: column,
+	}
+	if d.cfg.OnDuplicateKey != nil {
+		d.cfg.OnDuplicateKey(dup)
+	}
+	if d.cfg.DuplicateKeys == DuplicateError {
+		return &DuplicateKeyError{DuplicateKey: dup}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package zjson
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 测试各种重复键策略
+func TestDuplicateKeys_Policies(t *testing.T) {
+	input := `{"a": 1, "b": {"c": 1, "c": [2]}, "a": 3, "a": 4}`
+
+	obj, err := Config{}.ParseToJsonObject(input)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":4,"b":{"c":[2]}}`, obj.ToJsonStr())
+
+	obj, err = Config{DuplicateKeys: DuplicateFirstWins}.ParseToJsonObject(input)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":1,"b":{"c":1}}`, obj.ToJsonStr())
+
+	obj, err = Config{DuplicateKeys: DuplicateCollect}.ParseToJsonObject(input)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":[1,3,4],"b":{"c":[1,[2]]}}`, obj.ToJsonStr())
+
+	_, err = Config{DuplicateKeys: DuplicateError}.ParseToJsonObject(input)
+	var dupErr *DuplicateKeyError
+	assert.True(t, errors.As(err, &dupErr))
+	assert.ErrorIs(t, err, ErrDuplicateKey)
+	assert.Equal(t, "b.c", dupErr.Path)
+	assert.Equal(t, "c", dupErr.Key)
+	assert.Equal(t, 23, dupErr.Offset)
+	assert.EqualError(t, err, "duplicate key: 'b.c' at line 1, column 24 (offset 23)")
+}
+
+// 测试记录每个重复键的路径和位置，数组中的对象同样生效
+func TestDuplicateKeys_Report(t *testing.T) {
+	var dups []DuplicateKey
+	cfg := Config{OnDuplicateKey: func(dup DuplicateKey) {
+		dups = append(dups, dup)
+	}}
+	arr, err := cfg.ParseToArray("[{\"id\": 1},\n {\"id\": 2, \"id\": 3, \"id\": 4}]")
+	assert.Nil(t, err)
+	assert.Equal(t, 4, arr.GetJsonObjectIgnoreError(1).GetIntIgnoreError("id"))
+	assert.Equal(t, []DuplicateKey{
+		{Path: "1.id", Key: "id", Offset: 23, Line: 2, Column: 12},
+		{Path: "1.id", Key: "id", Offset: 32, Line: 2, Column: 21},
+	}, dups)
+
+	assert.Equal(t, "collect-into-array", DuplicateCollect.String())
+}
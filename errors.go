@@ -0,0 +1,100 @@
+package zjson
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrKeyNotFound     = errors.New("key does not exist")
+	ErrIndexOutOfRange = errors.New("index out of range")
+	ErrTypeMismatch    = errors.New("value type mismatch")
+	ErrParse           = errors.New("failed to parse JSON")
+)
+
+// PathError 描述读取某个位置时发生的错误，可以通过 errors.Is 判断 Err 对应的哨兵错误
+type PathError struct {
+	// Path 为出错的位置，对象使用键名，数组使用下标，嵌套路径以点号分隔，如 "items.0.price"
+	Path string
+	// Expected 和 Actual 为期望与实际的值类型，只在类型不匹配时设置
+	Expected string
+	Actual   string
+	Value    any
+	Err      error
+	msg      string
+}
+
+func (e *PathError) Error() string {
+	if e.msg != "" {
+		return e.msg
+	}
+	// Err 为 nil 时只输出路径和类型信息
+	var parts []string
+	if e.Err != nil {
+		parts = append(parts, e.Err.Error())
+	}
+	if e.Path != "" {
+		parts = append(parts, fmt.Sprintf("path '%s'", e.Path))
+	}
+	if e.Expected != "" {
+		expected := "expected " + e.Expected
+		if e.Actual != "" {
+			expected += ", got " + e.Actual
+		}
+		parts = append(parts, expected)
+	}
+	return strings.Join(parts, ": ")
+}
+
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+func keyNotFound(key string) error {
+	return &PathError{
+		Path: key,
+		Err:  ErrKeyNotFound,
+		msg:  fmt.Sprintf("%v: key '%s'", ErrKeyNotFound, key),
+	}
+}
+
+func outOfBounds(index, length int) error {
+	return &PathError{
+		Path: strconv.Itoa(index),
+		Err:  ErrIndexOutOfRange,
+		msg:  fmt.Sprintf("index %d out of bounds for array of length %d", index, length),
+	}
+}
+
+// keyTypeMismatch 的 kind 为带冠词的类型描述，如 "an integer"，reason 说明拒绝转换的规则
+func keyTypeMismatch(key, kind string, val any, reason error) error {
+	return &PathError{
+		Path:     key,
+		Expected: kindName(kind),
+		Actual:   typeName(val),
+		Value:    val,
+		Err:      ErrTypeMismatch,
+		msg:      fmt.Sprintf("%v: key '%s' is not %s: %v", ErrTypeMismatch, key, kind, reason),
+	}
+}
+
+func indexTypeMismatch(index int, kind string, val any, reason error) error {
+	return &PathError{
+		Path:     strconv.Itoa(index),
+		Expected: kindName(kind),
+		Actual:   typeName(val),
+		Value:    val,
+		Err:      ErrTypeMismatch,
+		msg:      fmt.Sprintf("value at index %d is not %s: %v", index, kind, reason),
+	}
+}
+
+func kindName(kind string) string {
+	if name, ok := strings.CutPrefix(kind, "an "); ok {
+		return name
+	}
+	name, _ := strings.CutPrefix(kind, "a ")
+	return name
+}
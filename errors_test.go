@@ -0,0 +1,110 @@
+package zjson
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrors_KeyAndIndex(t *testing.T) {
+	obj := NewJsonObject()
+	_, err := obj.GetInt("age")
+	assert.True(t, errors.Is(err, ErrKeyNotFound))
+	assert.EqualError(t, err, "key does not exist: key 'age'")
+	_, err = obj.GetJsonObject("address")
+	assert.True(t, errors.Is(err, ErrKeyNotFound))
+
+	arr := NewJsonArray()
+	_, err = arr.GetString(2)
+	assert.True(t, errors.Is(err, ErrIndexOutOfRange))
+	var pathErr *PathError
+	assert.True(t, errors.As(err, &pathErr))
+	assert.Equal(t, "2", pathErr.Path)
+	assert.True(t, errors.Is(arr.Set(0, 1), ErrIndexOutOfRange))
+}
+
+func TestErrors_TypeMismatch(t *testing.T) {
+	obj := NewJsonObject()
+	obj.Put("age", "old")
+	_, err := obj.GetInt("age")
+	assert.True(t, errors.Is(err, ErrTypeMismatch))
+
+	var pathErr *PathError
+	assert.True(t, errors.As(err, &pathErr))
+	assert.Equal(t, "age", pathErr.Path)
+	assert.Equal(t, "integer", pathErr.Expected)
+	assert.Equal(t, "string", pathErr.Actual)
+	assert.Equal(t, "old", pathErr.Value)
+
+	arr := NewJsonArray()
+	arr.AddAll(true, map[string]any{})
+	_, err = arr.GetFloat(1)
+	assert.True(t, errors.As(err, &pathErr))
+	assert.Equal(t, "1", pathErr.Path)
+	assert.Equal(t, "object", pathErr.Actual)
+
+	_, err = arr.ToFloatSlice()
+	assert.True(t, errors.Is(err, ErrTypeMismatch))
+
+	_, err = arr.Avg("price")
+	assert.True(t, errors.As(err, &pathErr))
+	assert.Equal(t, "price", pathErr.Path)
+	assert.EqualError(t, err, "value type mismatch: path 'price': expected number")
+}
+
+func TestErrors_Parse(t *testing.T) {
+	_, err := ParseToJsonObject(`{"a":`)
+	assert.True(t, errors.Is(err, ErrParse))
+	_, err = ParseToArray(`[1,`)
+	assert.True(t, errors.Is(err, ErrParse))
+	_, err = ParseToJsonObjectLazy(`[]`)
+	assert.True(t, errors.Is(err, ErrParse))
+}
+
+// 测试 Err 为 nil 的 PathError 可以正常格式化
+func TestErrors_PathErrorWithoutErr(t *testing.T) {
+	err := &PathError{Path: "items.0.price", Expected: "number", Actual: "string"}
+	assert.Equal(t, "path 'items.0.price': expected number, got string", err.Error())
+	assert.Nil(t, err.Unwrap())
+	assert.Equal(t, "", (&PathError{}).Error())
+
+	err = &PathError{Path: "a", Err: ErrKeyNotFound}
+	assert.Equal(t, "key does not exist: path 'a'", err.Error())
+}
+
+// 测试 GetJsonObject 和 GetJsonArray 读到不匹配的值时返回带路径的类型不匹配错误
+func TestErrors_ContainerTypeMismatch(t *testing.T) {
+	obj, err := ParseToJsonObject(`{"n":1,"s":"abc","b":true,"z":null,"list":[1],"obj":{},"text":"{\"a\":1}"}`)
+	assert.NoError(t, err)
+
+	var pathErr *PathError
+	for key, actual := range map[string]string{"n": "number", "s": "string", "b": "boolean", "z": "null", "list": "array"} {
+		_, err = obj.GetJsonObject(key)
+		assert.True(t, errors.Is(err, ErrTypeMismatch), key)
+		assert.True(t, errors.As(err, &pathErr), key)
+		assert.Equal(t, key, pathErr.Path)
+		assert.Equal(t, "object", pathErr.Expected)
+		assert.Equal(t, actual, pathErr.Actual)
+	}
+	_, err = obj.GetJsonArray("obj")
+	assert.True(t, errors.As(err, &pathErr))
+	assert.Equal(t, "array", pathErr.Expected)
+	assert.Equal(t, "object", pathErr.Actual)
+	text, err := obj.GetJsonObject("text")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, text.GetIntIgnoreError("a"))
+
+	arr, err := ParseToArray(`[1,{"a":1},"x"]`)
+	assert.NoError(t, err)
+	_, err = arr.GetJsonObject(0)
+	assert.True(t, errors.As(err, &pathErr))
+	assert.Equal(t, "0", pathErr.Path)
+	assert.Equal(t, "number", pathErr.Actual)
+	_, err = arr.GetJsonArray(1)
+	assert.True(t, errors.Is(err, ErrTypeMismatch))
+	_, err = arr.GetJsonArray(2)
+	assert.True(t, errors.As(err, &pathErr))
+	assert.Equal(t, "2", pathErr.Path)
+	assert.Equal(t, "string", pathErr.Actual)
+}
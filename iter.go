@@ -0,0 +1,204 @@
+package zjson
+
+import (
+	"iter"
+	"slices"
+	"sort"
+)
+
+// 迭代器均基于调用时的快照，循环体内可以安全地修改容器
+
+func (jo *JsonObject) Keys() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for _, key := range jo.orderedKeys() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+func (jo *JsonObject) All() iter.Seq2[string, any] {
+	return func(yield func(string, any) bool) {
+		keys, vals := jo.entries()
+		for i, key := range keys {
+			if !yield(key, plainValue(vals[i])) {
+				return
+			}
+		}
+	}
+}
+
+func (jo *JsonObject) Values() iter.Seq[any] {
+	return func(yield func(any) bool) {
+		for _, val := range jo.All() {
+			if !yield(val) {
+				return
+			}
+		}
+	}
+}
+
+// Objects 只遍历值为对象的键，返回的对象与 GetJsonObject 一样是实时视图，遍历的键集合同样基于调用时的快照
+func (jo *JsonObject) Objects() iter.Seq2[string, *JsonObject] {
+	return func(yield func(string, *JsonObject) bool) {
+		keys, objs := jo.attachObjects()
+		for i, key := range keys {
+			if !yield(key, objs[i]) {
+				return
+			}
+		}
+	}
+}
+
+func (jo *JsonObject) Strings() iter.Seq2[string, string] {
+	return func(yield func(string, string) bool) {
+		for key, val := range jo.All() {
+			strVal, ok := val.(string)
+			if !ok {
+				continue
+			}
+			if !yield(key, strVal) {
+				return
+			}
+		}
+	}
+}
+
+func (ja *JsonArray) All() iter.Seq2[int, any] {
+	return func(yield func(int, any) bool) {
+		for i, val := range ja.snapshot() {
+			if !yield(i, plainValue(val)) {
+				return
+			}
+		}
+	}
+}
+
+func (ja *JsonArray) Values() iter.Seq[any] {
+	return func(yield func(any) bool) {
+		for _, val := range ja.snapshot() {
+			if !yield(plainValue(val)) {
+				return
+			}
+		}
+	}
+}
+
+// Objects 只遍历对象元素，索引为元素在快照中的位置
+func (ja *JsonArray) Objects() iter.Seq2[int, *JsonObject] {
+	return func(yield func(int, *JsonObject) bool) {
+		for i, val := range ja.attachObjects() {
+			obj, ok := val.(*JsonObject)
+			if !ok {
+				continue
+			}
+			if !yield(i, obj) {
+				return
+			}
+		}
+	}
+}
+
+func (ja *JsonArray) Strings() iter.Seq2[int, string] {
+	return func(yield func(int, string) bool) {
+		for i, val := range ja.snapshot() {
+			strVal, ok := val.(string)
+			if !ok {
+				continue
+			}
+			if !yield(i, strVal) {
+				return
+			}
+		}
+	}
+}
+
+// orderedKeys 按容器配置的顺序返回键，KeysInsertion 模式下为插入顺序
+func (jo *JsonObject) orderedKeys() []string {
+	jo.mu.RLock()
+	defer jo.mu.RUnlock()
+	return jo.keyOrder()
+}
+
+// keyOrder 调用方需持有锁
+func (jo *JsonObject) keyOrder() []string {
+	if jo.cfg.ordered() {
+		return slices.Clone(jo.keys)
+	}
+	return sortedKeys(jo.data)
+}
+
+// entries 在同一次加锁中按顺序返回键和值，延迟解析的节点会被展开
+func (jo *JsonObject) entries() ([]string, []any) {
+	jo.mu.RLock()
+	defer jo.mu.RUnlock()
+	keys := jo.keyOrder()
+	vals := make([]any, len(keys))
+	for i, key := range keys {
+		vals[i] = resolve(jo.data[key])
+	}
+	return keys, vals
+}
+
+// attachObjects 将原生 map 值替换为共享底层数据的视图，在同一次加锁中按顺序返回对象值的键和视图
+func (jo *JsonObject) attachObjects() ([]string, []*JsonObject) {
+	jo.mu.Lock()
+	defer jo.mu.Unlock()
+	var (
+		keys []string
+		objs []*JsonObject
+	)
+	for _, key := range jo.keyOrder() {
+		switch v := resolve(jo.data[key]).(type) {
+		case *JsonObject:
+			keys, objs = append(keys, key), append(objs, v)
+		case map[string]any:
+			view := newObjectView(v, jo.cfg, jo.policy)
+			jo.data[key] = view
+			keys, objs = append(keys, key), append(objs, view)
+		}
+	}
+	return keys, objs
+}
+
+func sortedKeys(data map[string]any) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// shared 将原生 map 和切片元素替换为视图后返回快照，用于构造与源数组共享元素的新数组，
+// 两个数组持有同一个视图，通过任一数组修改元素都由该视图的锁保护
+func (ja *JsonArray) shared() []any {
+	ja.mu.Lock()
+	defer ja.mu.Unlock()
+	res := make([]any, len(ja.data))
+	for i, v := range ja.data {
+		v, attached := attachView(resolve(v), ja.cfg, ja.policy)
+		if attached {
+			ja.data[i] = v
+		}
+		res[i] = v
+	}
+	return res
+}
+
+// attachObjects 将原生 map 元素替换为共享底层数据的视图后返回快照
+func (ja *JsonArray) attachObjects() []any {
+	ja.mu.Lock()
+	defer ja.mu.Unlock()
+	res := make([]any, len(ja.data))
+	for i, v := range ja.data {
+		v = resolve(v)
+		if m, ok := v.(map[string]any); ok {
+			v = newObjectView(m, ja.cfg, ja.policy)
+			ja.data[i] = v
+		}
+		res[i] = v
+	}
+	return res
+}
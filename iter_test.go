@@ -0,0 +1,122 @@
+package zjson
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJsonObject_Iterators(t *testing.T) {
+	obj, err := ParseToJsonObject(`{"b":2,"a":"x","c":{"id":1},"d":{"id":2}}`)
+	assert.NoError(t, err)
+
+	var keys []string
+	for key := range obj.Keys() {
+		keys = append(keys, key)
+	}
+	assert.Equal(t, []string{"a", "b", "c", "d"}, keys)
+
+	// 遍历过程中修改容器不影响本次遍历
+	count := 0
+	for key := range obj.All() {
+		obj.Remove(key)
+		obj.Put(key+"_new", 1)
+		count++
+	}
+	assert.Equal(t, 4, count)
+	assert.Equal(t, 4, obj.Length())
+
+	obj, _ = ParseToJsonObject(`{"b":2,"a":"x","c":{"id":1},"d":{"id":2}}`)
+	values := 0
+	for range obj.Values() {
+		values++
+	}
+	assert.Equal(t, 4, values)
+
+	for _, child := range obj.Objects() {
+		child.Put("seen", true)
+	}
+	assert.True(t, obj.GetJsonObjectIgnoreError("c").GetBoolIgnoreError("seen"))
+
+	strs := map[string]string{}
+	for key, val := range obj.Strings() {
+		strs[key] = val
+	}
+	assert.Equal(t, map[string]string{"a": "x"}, strs)
+}
+
+// 测试 Objects 基于调用时的快照，遍历过程中替换或新增的值不影响本次遍历
+func TestJsonObject_ObjectsSnapshot(t *testing.T) {
+	obj, err := ParseToJsonObject(`{"a":{"id":1},"b":{"id":2},"c":3}`)
+	assert.NoError(t, err)
+
+	var seen []int
+	for key, child := range obj.Objects() {
+		seen = append(seen, child.GetIntIgnoreError("id"))
+		if key == "a" {
+			obj.Put("b", "replaced")
+			obj.Put("z", map[string]any{"id": 9})
+		}
+		child.Put("visited", true)
+	}
+	assert.Equal(t, []int{1, 2}, seen)
+	assert.True(t, obj.GetJsonObjectIgnoreError("a").GetBoolIgnoreError("visited"))
+	assert.Equal(t, "replaced", obj.GetStringIgnoreError("b"))
+}
+
+// 测试并发修改时 All 产生的键和值来自同一个快照
+func TestJsonObject_AllConcurrent(t *testing.T) {
+	obj := NewJsonObject()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 2000; i++ {
+			key := strconv.Itoa(i % 20)
+			obj.Put(key, i)
+			obj.Remove(strconv.Itoa((i + 7) % 20))
+		}
+	}()
+	for i := 0; i < 200; i++ {
+		for key, val := range obj.All() {
+			assert.NotNil(t, val, key)
+		}
+	}
+	wg.Wait()
+}
+
+func TestJsonArray_Iterators(t *testing.T) {
+	arr, err := ParseToArray(`["a",{"id":1},2,"b",{"id":2}]`)
+	assert.NoError(t, err)
+
+	for i, val := range arr.All() {
+		assert.Equal(t, arr.Get(i), val)
+		arr.Add("extra")
+	}
+	assert.Equal(t, 10, arr.Length())
+
+	var indexes []int
+	for i, obj := range arr.Objects() {
+		indexes = append(indexes, i)
+		obj.Put("seen", true)
+	}
+	assert.Equal(t, []int{1, 4}, indexes)
+	assert.True(t, arr.GetJsonObjectIgnoreError(4).GetBoolIgnoreError("seen"))
+
+	var strs []string
+	for _, val := range arr.Strings() {
+		strs = append(strs, val)
+		if len(strs) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []string{"a", "b"}, strs)
+
+	total := 0
+	for range arr.Values() {
+		total++
+	}
+	assert.Equal(t, 10, total)
+}
@@ -0,0 +1,142 @@
+package zjson
+
+type JoinType int
+
+const (
+	InnerJoin JoinType = iota
+	LeftJoin
+	FullOuterJoin
+)
+
+type JoinOptions struct {
+	Type JoinType
+	// LeftKey 和 RightKey 为两侧元素中连接键的路径，RightKey 为空时与 LeftKey 相同
+	LeftKey  string
+	RightKey string
+	// 两侧存在同名字段时分别加上前缀，都为空时右侧字段覆盖左侧
+	LeftPrefix  string
+	RightPrefix string
+}
+
+// JsonIndex 是按字段值建立的哈希索引，字段值按深度相等（数值等价）匹配
+type JsonIndex struct {
+	path    string
+	entries map[string][]*JsonObject
+}
+
+// IndexBy 为对象元素建立索引，字段缺失或为 null 的元素不会被索引
+func (ja *JsonArray) IndexBy(path string) *JsonIndex {
+	idx := &JsonIndex{
+		path:    path,
+		entries: make(map[string][]*JsonObject),
+	}
+	for _, val := range ja.attachObjects() {
+		obj, ok := val.(*JsonObject)
+		if !ok {
+			continue
+		}
+		fieldVal, exist := lookupPath(obj, path)
+		if !exist || fieldVal == nil {
+			continue
+		}
+		key := canonicalKey(fieldVal)
+		idx.entries[key] = append(idx.entries[key], obj)
+	}
+	return idx
+}
+
+func (idx *JsonIndex) Path() string {
+	return idx.path
+}
+
+func (idx *JsonIndex) Get(value any) []*JsonObject {
+	return idx.entries[canonicalKey(value)]
+}
+
+func (idx *JsonIndex) First(value any) (*JsonObject, bool) {
+	objs := idx.entries[canonicalKey(value)]
+	if len(objs) == 0 {
+		return nil, false
+	}
+	return objs[0], true
+}
+
+func (idx *JsonIndex) Contains(value any) bool {
+	return len(idx.entries[canonicalKey(value)]) > 0
+}
+
+// Len 返回不同键值的数量
+func (idx *JsonIndex) Len() int {
+	return len(idx.entries)
+}
+
+// Join 按连接键合并两个对象数组，一对多时产生多条结果，非对象元素被忽略
+func (ja *JsonArray) Join(right *JsonArray, opts JoinOptions) *JsonArray {
+	rightKey := opts.RightKey
+	if rightKey == "" {
+		rightKey = opts.LeftKey
+	}
+	rightIdx := right.IndexBy(rightKey)
+	matchedRight := make(map[*JsonObject]bool)
+
+	res := NewJsonArray()
+	for _, val := range ja.attachObjects() {
+		left, ok := val.(*JsonObject)
+		if !ok {
+			continue
+		}
+		var matches []*JsonObject
+		if fieldVal, exist := lookupPath(left, opts.LeftKey); exist && fieldVal != nil {
+			matches = rightIdx.Get(fieldVal)
+		}
+		for _, match := range matches {
+			matchedRight[match] = true
+			res.data = append(res.data, mergeJoined(left, match, opts))
+		}
+		if len(matches) == 0 && opts.Type != InnerJoin {
+			res.data = append(res.data, mergeJoined(left, nil, opts))
+		}
+	}
+
+	if opts.Type == FullOuterJoin {
+		for _, val := range right.attachObjects() {
+			if obj, ok := val.(*JsonObject); ok && !matchedRight[obj] {
+				res.data = append(res.data, mergeJoined(nil, obj, opts))
+			}
+		}
+	}
+	return res
+}
+
+func mergeJoined(left, right *JsonObject, opts JoinOptions) *JsonObject {
+	// 结果行使用深拷贝，修改结果不会影响参与连接的数组
+	var leftData, rightData map[string]any
+	if left != nil {
+		leftData = left.DeepClone().data
+	}
+	if right != nil {
+		rightData = right.DeepClone().data
+	}
+
+	res := NewJsonObject()
+	for k, v := range leftData {
+		res.data[k] = v
+	}
+	for k, v := range rightData {
+		leftVal, conflict := leftData[k]
+		if !conflict || deepEqual(leftVal, v, EqualOptions{NumericEquivalence: true}) {
+			res.data[k] = v
+			continue
+		}
+		if opts.LeftPrefix == "" && opts.RightPrefix == "" {
+			res.data[k] = v
+			continue
+		}
+		if opts.LeftPrefix != "" {
+			delete(res.data, k)
+			res.data[opts.LeftPrefix+k] = leftVal
+		}
+		res.data[opts.RightPrefix+k] = v
+	}
+	return res
+}
@@ -0,0 +1,84 @@
+package zjson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func joinFixtures(t *testing.T) (*JsonArray, *JsonArray) {
+	users, err := ParseToArray(`[
+		{"id":1,"name":"Alice","status":"active"},
+		{"id":2,"name":"Bob","status":"blocked"},
+		{"id":3,"name":"Carol"}
+	]`)
+	assert.NoError(t, err)
+	orders, err := ParseToArray(`[
+		{"user_id":1,"sku":"a","status":"paid"},
+		{"user_id":1,"sku":"b","status":"paid"},
+		{"user_id":2,"sku":"c","status":"active"},
+		{"user_id":9,"sku":"d","status":"lost"}
+	]`)
+	assert.NoError(t, err)
+	return users, orders
+}
+
+func TestJsonArray_Join(t *testing.T) {
+	users, orders := joinFixtures(t)
+
+	inner := users.Join(orders, JoinOptions{LeftKey: "id", RightKey: "user_id", RightPrefix: "order_"})
+	assert.JSONEq(t, `[
+		{"id":1,"name":"Alice","status":"active","user_id":1,"sku":"a","order_status":"paid"},
+		{"id":1,"name":"Alice","status":"active","user_id":1,"sku":"b","order_status":"paid"},
+		{"id":2,"name":"Bob","status":"blocked","user_id":2,"sku":"c","order_status":"active"}
+	]`, inner.ToJsonStr())
+
+	left := users.Join(orders, JoinOptions{Type: LeftJoin, LeftKey: "id", RightKey: "user_id", LeftPrefix: "user_", RightPrefix: "order_"})
+	assert.Equal(t, 4, left.Length())
+	assert.Equal(t, "blocked", left.GetJsonObjectIgnoreError(2).Get("user_status"))
+	assert.Equal(t, "Carol", left.GetJsonObjectIgnoreError(3).Get("name"))
+
+	full := users.Join(orders, JoinOptions{Type: FullOuterJoin, LeftKey: "id", RightKey: "user_id"})
+	assert.Equal(t, 5, full.Length())
+	assert.Equal(t, "d", full.GetJsonObjectIgnoreError(4).Get("sku"))
+	// 未设置前缀时右侧字段覆盖左侧
+	assert.Equal(t, "paid", full.GetJsonObjectIgnoreError(0).Get("status"))
+
+	// 合并结果不影响源数组
+	assert.Equal(t, "active", users.GetJsonObjectIgnoreError(0).Get("status"))
+}
+
+// 测试修改连接结果中的嵌套值不会影响原数组
+func TestJsonArray_JoinDeepCopy(t *testing.T) {
+	users, err := ParseToArray(`[{"id":1,"meta":{"x":1},"tags":["a"]}]`)
+	assert.NoError(t, err)
+	orders, err := ParseToArray(`[{"user_id":1,"items":[{"sku":"a"}]}]`)
+	assert.NoError(t, err)
+
+	joined := users.Join(orders, JoinOptions{LeftKey: "id", RightKey: "user_id"})
+	row := joined.GetJsonObjectIgnoreError(0)
+	row.GetJsonObjectIgnoreError("meta").Put("x", 99)
+	row.GetJsonArrayIgnoreError("tags").Add("b")
+	row.GetJsonArrayIgnoreError("items").GetJsonObjectIgnoreError(0).Put("sku", "z")
+
+	assert.JSONEq(t, `[{"id":1,"meta":{"x":1},"tags":["a"]}]`, users.ToJsonStr())
+	assert.JSONEq(t, `[{"user_id":1,"items":[{"sku":"a"}]}]`, orders.ToJsonStr())
+}
+
+func TestJsonArray_IndexBy(t *testing.T) {
+	_, orders := joinFixtures(t)
+
+	idx := orders.IndexBy("user_id")
+	assert.Equal(t, 3, idx.Len())
+	assert.Len(t, idx.Get(1), 2)
+	assert.True(t, idx.Contains(9))
+	assert.False(t, idx.Contains(5))
+
+	first, ok := idx.First(2)
+	assert.True(t, ok)
+	assert.Equal(t, "c", first.Get("sku"))
+
+	// 索引中的对象是源数组元素的实时视图
+	first.Put("sku", "z")
+	assert.Equal(t, "z", orders.GetJsonObjectIgnoreError(2).Get("sku"))
+}
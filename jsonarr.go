@@ -1,37 +1,64 @@
 package zjson
 
 import (
+	"encoding/json"
 	"fmt"
-	"strconv"
 	"sync"
+	"time"
 )
 
 type JsonArray struct {
-	data []any
-	mu   sync.RWMutex // 添加互斥锁以支持并发安全
+	data   []any
+	mu     sync.RWMutex // 添加互斥锁以支持并发安全
+	policy CoercionPolicy
+	cfg    *Config
 }
 
 func ParseToArray(v any) (*JsonArray, error) {
+	return parseArray(v, nil)
+}
+
+func parseArray(v any, cfg *Config) (*JsonArray, error) {
 	var strB []byte
-	if strP, ok := getPointVal[JsonArray](v); ok {
-		return strP, nil
+	if arr, ok := v.(*JsonArray); ok {
+		return arr, nil
+	} else if arr, ok := copyArrayValue(v); ok {
+		return arr, nil
 	} else if strP, ok := getPointVal[[]byte](v); ok {
 		strB = *strP
 	} else if strP, ok := getPointVal[string](v); ok {
 		strB = []byte(*strP)
-	} else if strByte, err := jsonParser.AnyToJsonString(v); err == nil {
-		return ParseToArray(string(strByte))
+	} else if strByte, err := cfg.parser().AnyToJsonString(v); err == nil {
+		return parseArray(string(strByte), cfg)
 	} else {
-		return nil, fmt.Errorf("failed to parse value: %w", err)
+		return nil, fmt.Errorf("%w: cannot encode value: %w", ErrParse, err)
+	}
+
+	if cfg.builtin() {
+		if err := cfg.checkInput(strB); err != nil {
+			return nil, err
+		}
+		val, err := newDecoder(strB, cfg).decode()
+		if err != nil {
+			return nil, err
+		}
+		switch v := val.(type) {
+		case []any:
+			return &JsonArray{data: v, cfg: cfg}, nil
+		case nil:
+			return &JsonArray{data: make([]any, 0), cfg: cfg}, nil
+		}
+		return nil, fmt.Errorf("%w: expected an array, got %s", ErrParse, typeName(val))
 	}
 
 	var arrayVal = make([]any, 0)
-	if err := jsonParser.JsonStringToAny(strB, &arrayVal); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON array: %w", err)
+	if err := cfg.parser().JsonStringToAny(strB, &arrayVal); err != nil {
+		return nil, parseFailed(strB, err)
 	}
 
 	return &JsonArray{
 		data: arrayVal,
+		cfg:  cfg,
 	}, nil
 }
 
@@ -47,13 +74,14 @@ func (ja *JsonArray) Add(value any) {
 	ja.data = append(ja.data, value)
 }
 
+// Get 与 JsonObject.Get 相同，返回的对象和数组可能与容器共享数据，并发读写的注意事项也相同
 func (ja *JsonArray) Get(index int) any {
 	ja.mu.RLock()
 	defer ja.mu.RUnlock()
 	if index < 0 || index >= len(ja.data) {
 		return nil
 	}
-	return ja.data[index]
+	return plainValue(ja.data[index])
 }
 
 func (ja *JsonArray) Remove(index int) {
@@ -72,33 +100,76 @@ func (ja *JsonArray) Length() int {
 }
 
 func (ja *JsonArray) ToJsonStr() string {
-	ja.mu.RLock()
-	defer ja.mu.RUnlock()
-	jsonStr, err := jsonParser.AnyToJsonString(ja.data)
+	jsonStr, err := ja.MarshalJSON()
 	if err != nil {
 		return "[]"
 	}
 	return string(jsonStr)
 }
 
-func (ja *JsonArray) ToStruct(s any) error {
+func (ja *JsonArray) MarshalJSON() ([]byte, error) {
+	ja.mu.RLock()
+	defer ja.mu.RUnlock()
+	return ja.cfg.encode(ja.data)
+}
+
+// snapshot 返回当前数据的浅拷贝，延迟解析的节点会被展开
+func (ja *JsonArray) snapshot() []any {
 	ja.mu.RLock()
 	defer ja.mu.RUnlock()
-	if err := jsonParser.JsonStringToAny([]byte(ja.ToJsonStr()), s); err != nil {
+	res := make([]any, len(ja.data))
+	for i, v := range ja.data {
+		res[i] = resolve(v)
+	}
+	return res
+}
+
+func (ja *JsonArray) ToStruct(s any) error {
+	if err := ja.cfg.parser().JsonStringToAny([]byte(ja.ToJsonStr()), s); err != nil {
 		return fmt.Errorf("failed to convert to struct: %w", err)
 	}
 	return nil
 }
 
 func (ja *JsonArray) GetJsonObject(index int) (*JsonObject, error) {
-	ja.mu.RLock()
-	defer ja.mu.RUnlock()
+	val, err := ja.child(index)
+	if err != nil {
+		return nil, err
+	}
+	return convertObject(val, ja.cfg, func(reason error) error { return indexTypeMismatch(index, "an object", val, reason) })
+}
 
+// child 读取元素，原生 map 和切片包装为共享底层数据的视图并写回，之后的访问都返回同一实例
+func (ja *JsonArray) child(index int) (any, error) {
+	ja.mu.RLock()
 	if index < 0 || index >= len(ja.data) {
-		return nil, fmt.Errorf("index %d out of bounds for array of length %d", index, len(ja.data))
+		length := len(ja.data)
+		ja.mu.RUnlock()
+		return nil, outOfBounds(index, length)
+	}
+	val := ja.data[index]
+	ja.mu.RUnlock()
+	val, err := resolveErr(val)
+	if err != nil {
+		return nil, err
+	}
+	switch val.(type) {
+	case map[string]any, []any:
+	default:
+		return val, nil
 	}
 
-	return ParseToJsonObject(ja.data[index])
+	ja.mu.Lock()
+	defer ja.mu.Unlock()
+	if index < 0 || index >= len(ja.data) {
+		return nil, outOfBounds(index, len(ja.data))
+	}
+	val = resolve(ja.data[index])
+	if view, attached := attachView(val, ja.cfg, ja.policy); attached {
+		ja.data[index] = view
+		return view, nil
+	}
+	return val, nil
 }
 
 func (ja *JsonArray) GetJsonObjectIgnoreError(index int) *JsonObject {
@@ -106,15 +177,20 @@ func (ja *JsonArray) GetJsonObjectIgnoreError(index int) *JsonObject {
 	return jsonObject
 }
 
-func (ja *JsonArray) GetJsonArray(index int) (*JsonArray, error) {
-	ja.mu.RLock()
-	defer ja.mu.RUnlock()
-
-	if index < 0 || index >= len(ja.data) {
-		return nil, fmt.Errorf("index %d out of bounds for array of length %d", index, len(ja.data))
+func (ja *JsonArray) GetJsonObjectClone(index int) (*JsonObject, error) {
+	jsonObject, err := ja.GetJsonObject(index)
+	if err != nil {
+		return nil, err
 	}
+	return jsonObject.DeepClone(), nil
+}
 
-	return ParseToArray(ja.data[index])
+func (ja *JsonArray) GetJsonArray(index int) (*JsonArray, error) {
+	val, err := ja.child(index)
+	if err != nil {
+		return nil, err
+	}
+	return convertArray(val, ja.cfg, func(reason error) error { return indexTypeMismatch(index, "an array", val, reason) })
 }
 
 func (ja *JsonArray) GetJsonArrayIgnoreError(index int) *JsonArray {
@@ -122,28 +198,16 @@ func (ja *JsonArray) GetJsonArrayIgnoreError(index int) *JsonArray {
 	return jsonArray
 }
 
-func (ja *JsonArray) GetInt(index int) (int, error) {
-	ja.mu.RLock()
-	defer ja.mu.RUnlock()
-
-	if index < 0 || index >= len(ja.data) {
-		return 0, fmt.Errorf("index %d out of bounds for array of length %d", index, len(ja.data))
+func (ja *JsonArray) GetJsonArrayClone(index int) (*JsonArray, error) {
+	jsonArray, err := ja.GetJsonArray(index)
+	if err != nil {
+		return nil, err
 	}
+	return jsonArray.DeepClone(), nil
+}
 
-	val := ja.data[index]
-	switch v := val.(type) {
-	case int:
-		return v, nil
-	case int64:
-		return int(v), nil
-	case float64:
-		return int(v), nil
-	case string:
-		if number, err := strconv.ParseInt(v, 10, 64); err == nil {
-			return int(number), nil
-		}
-	}
-	return 0, fmt.Errorf("value at index %d is not an integer", index)
+func (ja *JsonArray) GetInt(index int) (int, error) {
+	return getByIndex(ja, index, "an integer", toInt)
 }
 
 func (ja *JsonArray) GetIntIgnoreError(index int) int {
@@ -152,27 +216,7 @@ func (ja *JsonArray) GetIntIgnoreError(index int) int {
 }
 
 func (ja *JsonArray) GetFloat(index int) (float64, error) {
-	ja.mu.RLock()
-	defer ja.mu.RUnlock()
-
-	if index < 0 || index >= len(ja.data) {
-		return 0, fmt.Errorf("index %d out of bounds for array of length %d", index, len(ja.data))
-	}
-
-	val := ja.data[index]
-	switch v := val.(type) {
-	case float64:
-		return v, nil
-	case int:
-		return float64(v), nil
-	case int64:
-		return float64(v), nil
-	case string:
-		if number, err := strconv.ParseFloat(v, 64); err == nil {
-			return number, nil
-		}
-	}
-	return 0, fmt.Errorf("value at index %d is not a float", index)
+	return getByIndex(ja, index, "a float", toFloat)
 }
 
 func (ja *JsonArray) GetFloatIgnoreError(index int) float64 {
@@ -181,21 +225,88 @@ func (ja *JsonArray) GetFloatIgnoreError(index int) float64 {
 }
 
 func (ja *JsonArray) GetString(index int) (string, error) {
+	return getByIndex(ja, index, "a string", toString)
+}
+
+func (ja *JsonArray) GetStringIgnoreError(index int) string {
+	strVal, _ := ja.GetString(index)
+	return strVal
+}
+
+func (ja *JsonArray) GetBool(index int) (bool, error) {
+	return getByIndex(ja, index, "a boolean", toBool)
+}
+
+func (ja *JsonArray) GetBoolIgnoreError(index int) bool {
+	val, _ := ja.GetBool(index)
+	return val
+}
+
+func (ja *JsonArray) GetInt64(index int) (int64, error) {
+	return getByIndex(ja, index, "an integer", toInt64)
+}
+
+func (ja *JsonArray) GetInt64IgnoreError(index int) int64 {
+	val, _ := ja.GetInt64(index)
+	return val
+}
+
+func (ja *JsonArray) GetInt32(index int) (int32, error) {
+	return getByIndex(ja, index, "a 32-bit integer", toInt32)
+}
+
+func (ja *JsonArray) GetInt32IgnoreError(index int) int32 {
+	val, _ := ja.GetInt32(index)
+	return val
+}
+
+func (ja *JsonArray) GetUint(index int) (uint, error) {
+	return getByIndex(ja, index, "an unsigned integer", toUint)
+}
+
+func (ja *JsonArray) GetUintIgnoreError(index int) uint {
+	val, _ := ja.GetUint(index)
+	return val
+}
+
+func (ja *JsonArray) GetTime(index int) (time.Time, error) {
+	return getByIndex(ja, index, "a time", toTime)
+}
+
+func (ja *JsonArray) GetTimeIgnoreError(index int) time.Time {
+	val, _ := ja.GetTime(index)
+	return val
+}
+
+func (ja *JsonArray) GetDuration(index int) (time.Duration, error) {
+	return getByIndex(ja, index, "a duration", toDuration)
+}
+
+func (ja *JsonArray) GetDurationIgnoreError(index int) time.Duration {
+	val, _ := ja.GetDuration(index)
+	return val
+}
+
+func (ja *JsonArray) GetBytes(index int) ([]byte, error) {
+	return getByIndex(ja, index, "base64 encoded bytes", toBytes)
+}
+
+func (ja *JsonArray) GetBytesIgnoreError(index int) []byte {
+	val, _ := ja.GetBytes(index)
+	return val
+}
+
+// GetRaw 返回元素的 JSON 文本，延迟解析且未访问过的节点直接返回原始字节
+func (ja *JsonArray) GetRaw(index int) (json.RawMessage, error) {
 	ja.mu.RLock()
 	defer ja.mu.RUnlock()
 
 	if index < 0 || index >= len(ja.data) {
-		return "", fmt.Errorf("index %d out of bounds for array of length %d", index, len(ja.data))
+		return nil, outOfBounds(index, len(ja.data))
 	}
-
-	val := ja.data[index]
-	if strVal, ok := val.(string); ok {
-		return strVal, nil
+	raw, err := marshalRaw(ja.data[index])
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal value at index %d: %w", index, err)
 	}
-	return fmt.Sprint(val), nil
-}
-
-func (ja *JsonArray) GetStringIgnoreError(index int) string {
-	strVal, _ := ja.GetString(index)
-	return strVal
+	return raw, nil
 }
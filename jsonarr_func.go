@@ -0,0 +1,186 @@
+package zjson
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// 以下操作都先取快照，回调执行期间不持有源数组的锁，结果为新数组，继承源数组的配置和策略，
+// 对象和数组元素与源数组共享同一个视图，Map 和 FlatMap 的结果为回调返回值的副本；
+// 回调收到的值与 Get 一致，对象和数组为 map[string]any 和 []any
+
+func (ja *JsonArray) Filter(pred func(value any) bool) *JsonArray {
+	res := ja.derive(nil)
+	for _, val := range ja.shared() {
+		if pred(plainValue(val)) {
+			res.data = append(res.data, val)
+		}
+	}
+	return res
+}
+
+// FilterObjects 只保留满足条件的对象元素，非对象元素被丢弃
+func (ja *JsonArray) FilterObjects(pred func(obj *JsonObject) bool) *JsonArray {
+	res := ja.derive(nil)
+	for _, val := range ja.attachObjects() {
+		if obj, ok := val.(*JsonObject); ok && pred(obj) {
+			res.data = append(res.data, obj)
+		}
+	}
+	return res
+}
+
+func (ja *JsonArray) Map(fn func(value any) any) *JsonArray {
+	snapshot := ja.snapshot()
+	res := ja.derive(make([]any, len(snapshot)))
+	for i, val := range snapshot {
+		res.data[i] = cloneValue(fn(plainValue(val)))
+	}
+	return res
+}
+
+// MapObjects 只对对象元素调用 fn，非对象元素被丢弃
+func (ja *JsonArray) MapObjects(fn func(obj *JsonObject) any) *JsonArray {
+	res := ja.derive(nil)
+	for _, val := range ja.attachObjects() {
+		if obj, ok := val.(*JsonObject); ok {
+			res.data = append(res.data, fn(obj))
+		}
+	}
+	return res
+}
+
+func (ja *JsonArray) FlatMap(fn func(value any) []any) *JsonArray {
+	res := ja.derive(nil)
+	for _, val := range ja.snapshot() {
+		for _, item := range fn(plainValue(val)) {
+			res.data = append(res.data, cloneValue(item))
+		}
+	}
+	return res
+}
+
+func (ja *JsonArray) Reduce(initial any, fn func(acc any, value any) any) any {
+	acc := initial
+	for _, val := range ja.snapshot() {
+		acc = fn(acc, plainValue(val))
+	}
+	return acc
+}
+
+func (ja *JsonArray) AnyMatch(pred func(value any) bool) bool {
+	for _, val := range ja.snapshot() {
+		if pred(plainValue(val)) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllMatch 空数组返回 true
+func (ja *JsonArray) AllMatch(pred func(value any) bool) bool {
+	for _, val := range ja.snapshot() {
+		if !pred(plainValue(val)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (ja *JsonArray) Find(pred func(value any) bool) (any, bool) {
+	for _, val := range ja.snapshot() {
+		if val = plainValue(val); pred(val) {
+			return val, true
+		}
+	}
+	return nil, false
+}
+
+func (ja *JsonArray) FindObject(pred func(obj *JsonObject) bool) (*JsonObject, bool) {
+	for _, val := range ja.attachObjects() {
+		if obj, ok := val.(*JsonObject); ok && pred(obj) {
+			return obj, true
+		}
+	}
+	return nil, false
+}
+
+// Distinct 按深度相等去重，数字按数值比较，保留首次出现的元素
+func (ja *JsonArray) Distinct() *JsonArray {
+	return ja.Dedupe()
+}
+
+// Chunk 按每组 size 个元素拆分，最后一组可能不足 size，size 小于 1 时返回 nil
+func (ja *JsonArray) Chunk(size int) []*JsonArray {
+	if size < 1 {
+		return nil
+	}
+	snapshot := ja.shared()
+	res := make([]*JsonArray, 0, (len(snapshot)+size-1)/size)
+	for from := 0; from < len(snapshot); from += size {
+		to := min(from+size, len(snapshot))
+		res = append(res, ja.derive(snapshot[from:to:to]))
+	}
+	return res
+}
+
+func (ja *JsonArray) Partition(pred func(value any) bool) (matched *JsonArray, rest *JsonArray) {
+	matched, rest = ja.derive(nil), ja.derive(nil)
+	for _, val := range ja.shared() {
+		if pred(plainValue(val)) {
+			matched.data = append(matched.data, val)
+		} else {
+			rest.data = append(rest.data, val)
+		}
+	}
+	return matched, rest
+}
+
+// derive 创建继承配置和策略的新数组，data 为 nil 时创建空数组
+func (ja *JsonArray) derive(data []any) *JsonArray {
+	if data == nil {
+		data = make([]any, 0)
+	}
+	ja.mu.RLock()
+	defer ja.mu.RUnlock()
+	return &JsonArray{data: data, cfg: ja.cfg, policy: ja.policy}
+}
+
+// canonicalKey 生成与深度相等（数值等价）一致的比较键
+func canonicalKey(val any) string {
+	jsonStr, err := json.Marshal(canonicalValue(val))
+	if err != nil {
+		return fmt.Sprintf("%T:%v", val, val)
+	}
+	return string(jsonStr)
+}
+
+func canonicalValue(val any) any {
+	val = normalizeValue(val)
+	if n, ok := toNumber(val); ok {
+		if n.isInt {
+			return n.i
+		}
+		if math.Abs(n.f) < math.MaxInt64 && n.f == math.Trunc(n.f) {
+			return int64(n.f)
+		}
+		return n.f
+	}
+
+	switch v := val.(type) {
+	case map[string]any:
+		res := make(map[string]any, len(v))
+		for k, item := range v {
+			res[k] = canonicalValue(item)
+		}
+		return res
+	case []any:
+		res := make([]any, len(v))
+		for i, item := range v {
+			res[i] = canonicalValue(item)
+		}
+		return res
+	}
+	return val
+}
@@ -0,0 +1,131 @@
+package zjson
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJsonArray_FilterMap(t *testing.T) {
+	arr, err := ParseToArray(`[1,2,3,4,"x"]`)
+	assert.NoError(t, err)
+
+	even := arr.Filter(func(v any) bool {
+		f, ok := v.(float64)
+		return ok && int(f)%2 == 0
+	})
+	assert.JSONEq(t, `[2,4]`, even.ToJsonStr())
+
+	strs := arr.Map(func(v any) any { return v == "x" })
+	assert.JSONEq(t, `[false,false,false,false,true]`, strs.ToJsonStr())
+
+	flat := arr.FlatMap(func(v any) []any { return []any{v, v} })
+	assert.Equal(t, 10, flat.Length())
+
+	sum := arr.Reduce(0.0, func(acc, v any) any {
+		if f, ok := v.(float64); ok {
+			return acc.(float64) + f
+		}
+		return acc
+	})
+	assert.Equal(t, 10.0, sum)
+}
+
+func TestJsonArray_ObjectCallbacks(t *testing.T) {
+	arr, err := ParseToArray(`[{"name":"a","age":20},{"name":"b","age":35},"skip"]`)
+	assert.NoError(t, err)
+
+	adults := arr.FilterObjects(func(obj *JsonObject) bool {
+		return obj.GetIntIgnoreError("age") > 30
+	})
+	assert.JSONEq(t, `[{"name":"b","age":35}]`, adults.ToJsonStr())
+
+	names := arr.MapObjects(func(obj *JsonObject) any { return obj.GetStringIgnoreError("name") })
+	assert.JSONEq(t, `["a","b"]`, names.ToJsonStr())
+
+	obj, ok := arr.FindObject(func(obj *JsonObject) bool { return obj.GetStringIgnoreError("name") == "a" })
+	assert.True(t, ok)
+	// 回调中可以安全访问源数组
+	obj.Put("count", arr.Length())
+	assert.Equal(t, 3, arr.GetJsonObjectIgnoreError(0).GetIntIgnoreError("count"))
+}
+
+func TestJsonArray_Predicates(t *testing.T) {
+	arr := NewJsonArray()
+	arr.AddAll(1, 2, 3)
+
+	assert.True(t, arr.AnyMatch(func(v any) bool { return v == 2 }))
+	assert.False(t, arr.AllMatch(func(v any) bool { return v == 2 }))
+	assert.True(t, NewJsonArray().AllMatch(func(v any) bool { return false }))
+
+	val, ok := arr.Find(func(v any) bool { return v.(int) > 1 })
+	assert.True(t, ok)
+	assert.Equal(t, 2, val)
+	_, ok = arr.Find(func(v any) bool { return v.(int) > 5 })
+	assert.False(t, ok)
+
+	matched, rest := arr.Partition(func(v any) bool { return v.(int) > 1 })
+	assert.JSONEq(t, `[2,3]`, matched.ToJsonStr())
+	assert.JSONEq(t, `[1]`, rest.ToJsonStr())
+}
+
+func TestJsonArray_DistinctChunk(t *testing.T) {
+	arr := NewJsonArray()
+	arr.AddAll(1, 1.0, "1", map[string]any{"a": 1}, map[string]any{"a": 1.0}, []any{1, 2})
+
+	distinct := arr.Distinct()
+	assert.Equal(t, 4, distinct.Length())
+	assert.Equal(t, 1, distinct.Get(0))
+
+	chunks := arr.Chunk(4)
+	assert.Len(t, chunks, 2)
+	assert.Equal(t, 4, chunks[0].Length())
+	assert.Equal(t, 2, chunks[1].Length())
+
+	// 对分组追加元素不会覆盖下一组
+	chunks[0].Add("x")
+	assert.Equal(t, map[string]any{"a": 1.0}, chunks[1].Get(0))
+	assert.Nil(t, arr.Chunk(0))
+}
+
+// 测试结果数组继承源数组的策略，并与源数组共享同一个对象视图
+func TestJsonArray_FuncResultsShareViews(t *testing.T) {
+	src := NewJsonArray()
+	src.AddAll(map[string]any{"id": 1}, "1", []any{map[string]any{"id": 2}})
+	src.SetCoercionPolicy(CoercionStrict)
+
+	filtered := src.Filter(func(any) bool { return true })
+	matched, rest := src.Partition(func(v any) bool { return v != "1" })
+	chunks := src.Chunk(2)
+	flat := src.FlatMap(func(v any) []any {
+		if items, ok := v.([]any); ok {
+			return items
+		}
+		return nil
+	})
+	for _, res := range []*JsonArray{filtered, matched, rest, chunks[0], flat} {
+		assert.Equal(t, CoercionStrict, res.CoercionPolicy())
+	}
+	_, err := filtered.GetInt(1)
+	assert.ErrorIs(t, err, ErrTypeMismatch)
+	assert.Same(t, src.GetJsonObjectIgnoreError(0), matched.GetJsonObjectIgnoreError(0))
+
+	var wg sync.WaitGroup
+	for i, res := range []*JsonArray{src, filtered, matched, chunks[0]} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res.GetJsonObjectIgnoreError(0).Put("k"+strconv.Itoa(i), i)
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		flat.GetJsonObjectIgnoreError(0).Put("flat", true)
+	}()
+	wg.Wait()
+	assert.Equal(t, 5, src.GetJsonObjectIgnoreError(0).Length())
+	assert.Equal(t, `[{"id":2}]`, src.GetJsonArrayIgnoreError(2).ToJsonStr())
+}
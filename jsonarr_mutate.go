@@ -0,0 +1,205 @@
+package zjson
+
+import (
+	"math"
+	"reflect"
+)
+
+func (ja *JsonArray) Set(index int, value any) error {
+	ja.mu.Lock()
+	defer ja.mu.Unlock()
+	if index < 0 || index >= len(ja.data) {
+		return outOfBounds(index, len(ja.data))
+	}
+	ja.data[index] = value
+	return nil
+}
+
+// Insert 在 index 位置插入元素，index 等于长度时相当于 Add
+func (ja *JsonArray) Insert(index int, value any) error {
+	return ja.InsertAll(index, value)
+}
+
+func (ja *JsonArray) InsertAll(index int, values ...any) error {
+	ja.mu.Lock()
+	defer ja.mu.Unlock()
+	if index < 0 || index > len(ja.data) {
+		return outOfBounds(index, len(ja.data))
+	}
+	data := make([]any, 0, len(ja.data)+len(values))
+	data = append(data, ja.data[:index]...)
+	data = append(data, values...)
+	ja.data = append(data, ja.data[index:]...)
+	return nil
+}
+
+func (ja *JsonArray) AddAll(values ...any) {
+	ja.mu.Lock()
+	defer ja.mu.Unlock()
+	ja.data = append(ja.data, values...)
+}
+
+func AddSlice[T any](ja *JsonArray, values []T) {
+	items := make([]any, len(values))
+	for i, v := range values {
+		items[i] = v
+	}
+	ja.AddAll(items...)
+}
+
+func (ja *JsonArray) Extend(other *JsonArray) {
+	if other == nil {
+		return
+	}
+	// 先取快照再加锁，避免两个数组互相扩展时死锁
+	ja.AddAll(other.snapshot()...)
+}
+
+// Slice 返回 [from, to) 区间的新数组，负数索引从末尾开始计算，对象和数组元素与源数组共享
+func (ja *JsonArray) Slice(from, to int) (*JsonArray, error) {
+	snapshot := ja.shared()
+	length := len(snapshot)
+	if from < 0 {
+		from += length
+	}
+	if to < 0 {
+		to += length
+	}
+	if from < 0 || from > length {
+		return nil, outOfBounds(from, length)
+	}
+	if to < from || to > length {
+		return nil, outOfBounds(to, length)
+	}
+
+	data := make([]any, to-from)
+	copy(data, snapshot[from:to])
+	return ja.derive(data), nil
+}
+
+func (ja *JsonArray) Clear() {
+	ja.mu.Lock()
+	defer ja.mu.Unlock()
+	ja.data = make([]any, 0)
+}
+
+func (ja *JsonArray) Reverse() {
+	ja.mu.Lock()
+	defer ja.mu.Unlock()
+	for i, j := 0, len(ja.data)-1; i < j; i, j = i+1, j-1 {
+		ja.data[i], ja.data[j] = ja.data[j], ja.data[i]
+	}
+}
+
+func (ja *JsonArray) Swap(i, j int) error {
+	ja.mu.Lock()
+	defer ja.mu.Unlock()
+	if i < 0 || i >= len(ja.data) {
+		return outOfBounds(i, len(ja.data))
+	}
+	if j < 0 || j >= len(ja.data) {
+		return outOfBounds(j, len(ja.data))
+	}
+	ja.data[i], ja.data[j] = ja.data[j], ja.data[i]
+	return nil
+}
+
+// IndexOf 按深度相等查找元素，数字按数值比较，找不到返回 -1
+func (ja *JsonArray) IndexOf(value any) int {
+	for i, val := range ja.snapshot() {
+		if deepEqual(val, value, EqualOptions{NumericEquivalence: true}) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (ja *JsonArray) Contains(value any) bool {
+	return ja.IndexOf(value) >= 0
+}
+
+// RemoveIf 删除满足条件的元素并返回删除数量，pred 收到的值与 Get 一致。
+// pred 在快照上对每个元素只调用一次且不持有锁，可以访问当前数组；
+// 执行期间并发加入或替换的元素不会被删除
+func (ja *JsonArray) RemoveIf(pred func(value any) bool) int {
+	snapshot := ja.snapshot()
+	remove := make([]bool, len(snapshot))
+	for i, val := range snapshot {
+		remove[i] = pred(plainValue(val))
+	}
+	return ja.removeMarked(snapshot, remove)
+}
+
+// removeMarked 删除快照中被标记的元素，快照之后加入或替换的元素保留
+func (ja *JsonArray) removeMarked(snapshot []any, remove []bool) int {
+	ja.mu.Lock()
+	defer ja.mu.Unlock()
+	match := ja.matchSnapshot(snapshot)
+	data := ja.data[:0]
+	for i, val := range ja.data {
+		if j := match[i]; j >= 0 && remove[j] {
+			continue
+		}
+		data = append(data, val)
+	}
+	removed := len(ja.data) - len(data)
+	clear(ja.data[len(data):])
+	ja.data = data
+	return removed
+}
+
+// matchSnapshot 将当前元素与快照元素按 sameElement 一一匹配，返回每个当前元素对应的快照下标，
+// 快照之后加入或替换的元素为 -1，调用方需持有锁
+func (ja *JsonArray) matchSnapshot(snapshot []any) []int {
+	match := make([]int, len(ja.data))
+	unchanged := len(ja.data) == len(snapshot)
+	for i := 0; unchanged && i < len(ja.data); i++ {
+		unchanged = sameElement(resolve(ja.data[i]), snapshot[i])
+		match[i] = i
+	}
+	if unchanged {
+		return match
+	}
+
+	used := make([]bool, len(snapshot))
+	next := 0
+	for i, val := range ja.data {
+		val = resolve(val)
+		match[i] = -1
+		// 先尝试紧接上一个匹配的位置，插入和追加元素时无需整体扫描
+		if next < len(snapshot) && !used[next] && sameElement(val, snapshot[next]) {
+			match[i], used[next] = next, true
+			next++
+			continue
+		}
+		for j, item := range snapshot {
+			if !used[j] && sameElement(val, item) {
+				match[i], used[j] = j, true
+				next = j + 1
+				break
+			}
+		}
+	}
+	return match
+}
+
+// sameElement 判断两个元素是否为同一个值，map、切片等引用类型比较底层数据的地址
+func sameElement(a, b any) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	ra, rb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if ra.Type() != rb.Type() {
+		return false
+	}
+	switch ra.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Pointer, reflect.UnsafePointer:
+		return ra.Pointer() == rb.Pointer() && (ra.Kind() != reflect.Slice || ra.Len() == rb.Len())
+	case reflect.Float32, reflect.Float64:
+		// 按位比较，NaN 与自身相同
+		return math.Float64bits(ra.Float()) == math.Float64bits(rb.Float())
+	case reflect.Struct, reflect.Array, reflect.Interface:
+		return reflect.DeepEqual(a, b)
+	}
+	return a == b
+}
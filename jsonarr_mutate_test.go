@@ -0,0 +1,161 @@
+package zjson
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJsonArray_SetInsert(t *testing.T) {
+	arr := NewJsonArray()
+	arr.AddAll(1, 2, 3)
+
+	assert.NoError(t, arr.Set(0, "a"))
+	assert.EqualError(t, arr.Set(3, "x"), "index 3 out of bounds for array of length 3")
+
+	assert.NoError(t, arr.Insert(1, "b"))
+	assert.NoError(t, arr.Insert(4, "end"))
+	assert.NoError(t, arr.InsertAll(0, "x", "y"))
+	assert.Error(t, arr.Insert(-1, "z"))
+	assert.JSONEq(t, `["x","y","a","b",2,3,"end"]`, arr.ToJsonStr())
+}
+
+func TestJsonArray_AddAllExtend(t *testing.T) {
+	arr := NewJsonArray()
+	AddSlice(arr, []int{1, 2})
+	AddSlice(arr, []string{"a"})
+
+	other := NewJsonArray()
+	other.Add(true)
+	arr.Extend(other)
+	arr.Extend(arr)
+	assert.JSONEq(t, `[1,2,"a",true,1,2,"a",true]`, arr.ToJsonStr())
+}
+
+func TestJsonArray_Slice(t *testing.T) {
+	arr := NewJsonArray()
+	arr.AddAll(0, 1, 2, 3, 4)
+
+	sub, err := arr.Slice(1, 3)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[1,2]`, sub.ToJsonStr())
+
+	sub, err = arr.Slice(-2, 5)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[3,4]`, sub.ToJsonStr())
+
+	sub, err = arr.Slice(0, -1)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, sub.Length())
+
+	_, err = arr.Slice(3, 1)
+	assert.Error(t, err)
+	_, err = arr.Slice(0, 6)
+	assert.Error(t, err)
+
+	// 切片与原数组互不影响
+	sub.Add(9)
+	assert.Equal(t, 5, arr.Length())
+}
+
+func TestJsonArray_ReorderAndSearch(t *testing.T) {
+	arr, err := ParseToArray(`[1,"a",{"id":1},[1,2]]`)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 0, arr.IndexOf(1))
+	assert.Equal(t, 2, arr.IndexOf(map[string]any{"id": 1}))
+	assert.True(t, arr.Contains([]any{1, 2}))
+	assert.False(t, arr.Contains("b"))
+
+	arr.Reverse()
+	assert.Equal(t, "a", arr.Get(2))
+	assert.NoError(t, arr.Swap(0, 3))
+	assert.Error(t, arr.Swap(0, 4))
+	assert.Equal(t, 1.0, arr.Get(0))
+
+	arr.Clear()
+	assert.Equal(t, 0, arr.Length())
+}
+
+func TestJsonArray_RemoveIf(t *testing.T) {
+	arr := NewJsonArray()
+	arr.AddAll(1, 2, 3, 4, 5)
+
+	removed := arr.RemoveIf(func(v any) bool {
+		return v.(int)%2 == 0
+	})
+	assert.Equal(t, 2, removed)
+	assert.JSONEq(t, `[1,3,5]`, arr.ToJsonStr())
+}
+
+// 测试 pred 中可以读取和修改当前数组，每个元素只调用一次，并发加入的元素不会被删除
+func TestJsonArray_RemoveIfReentrant(t *testing.T) {
+	arr := NewJsonArray()
+	arr.AddAll(1, 2, 3, math.NaN(), map[string]any{"a": 1})
+
+	removed := arr.RemoveIf(func(v any) bool {
+		return arr.Length() > 0 && v == 2
+	})
+	assert.Equal(t, 1, removed)
+	assert.Equal(t, 4, arr.Length())
+
+	arr = NewJsonArray()
+	arr.AddAll(1, 2, 3, 2, 2)
+	calls := 0
+	removed = arr.RemoveIf(func(v any) bool {
+		calls++
+		if calls == 1 {
+			arr.Insert(0, 2)
+			arr.Add(4)
+		}
+		return v == 2 || v == 4
+	})
+	assert.Equal(t, 5, calls)
+	assert.Equal(t, 3, removed)
+	assert.Equal(t, `[1,3,2,4]`, arr.ToJsonStr())
+}
+
+// 测试 Slice 与源数组共享同一个对象视图，通过两者并发修改不会产生数据竞争
+func TestJsonArray_SliceSharedElements(t *testing.T) {
+	src := NewJsonArray()
+	src.AddAll(map[string]any{"id": 1}, []any{1})
+	sl, err := src.Slice(0, 2)
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			src.GetJsonObjectIgnoreError(0).Put("src"+strconv.Itoa(i), i)
+			src.GetJsonArrayIgnoreError(1).Add(i)
+		}()
+		go func() {
+			defer wg.Done()
+			sl.GetJsonObjectIgnoreError(0).Put("sl"+strconv.Itoa(i), i)
+			sl.GetJsonArrayIgnoreError(1).Add(i)
+		}()
+	}
+	wg.Wait()
+	assert.Same(t, src.GetJsonObjectIgnoreError(0), sl.GetJsonObjectIgnoreError(0))
+	assert.Equal(t, 21, sl.GetJsonObjectIgnoreError(0).Length())
+	assert.Equal(t, 21, src.GetJsonArrayIgnoreError(1).Length())
+}
+
+// 测试 Slice 保留源数组的配置和策略
+func TestJsonArray_SliceKeepsSettings(t *testing.T) {
+	arr, err := Config{Numbers: NumberInt64, KeyOrder: KeysInsertion}.ParseToArray(`[{"b":1,"a":2},{"z":1,"y":2},3]`)
+	assert.NoError(t, err)
+	arr.SetCoercionPolicy(CoercionStrict)
+
+	sliced, err := arr.Slice(0, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, CoercionStrict, sliced.CoercionPolicy())
+	assert.Equal(t, KeysInsertion, sliced.Config().KeyOrder)
+	sliced.Add(map[string]any{"k": 1})
+	assert.Equal(t, `[{"b":1,"a":2},{"z":1,"y":2},{"k":1}]`, sliced.ToJsonStr())
+	assert.Equal(t, int64(1), sliced.GetJsonObjectIgnoreError(2).GetInt64IgnoreError("k"))
+}
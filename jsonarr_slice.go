@@ -0,0 +1,62 @@
+package zjson
+
+import "errors"
+
+// 批量转换只加一次锁，所有转换失败的下标会合并到同一个错误中返回
+
+func (ja *JsonArray) ToIntSlice() ([]int, error) {
+	return convertSlice(ja, "an integer", toInt)
+}
+
+func (ja *JsonArray) ToInt64Slice() ([]int64, error) {
+	return convertSlice(ja, "an integer", toInt64)
+}
+
+func (ja *JsonArray) ToFloatSlice() ([]float64, error) {
+	return convertSlice(ja, "a float", toFloat)
+}
+
+func (ja *JsonArray) ToStringSlice() ([]string, error) {
+	return convertSlice(ja, "a string", toString)
+}
+
+func (ja *JsonArray) ToBoolSlice() ([]bool, error) {
+	return convertSlice(ja, "a boolean", toBool)
+}
+
+// ToObjectSlice 返回的对象与 GetJsonObject 一样是元素的实时视图
+func (ja *JsonArray) ToObjectSlice() ([]*JsonObject, error) {
+	return convertSlice(&JsonArray{data: ja.attachObjects()}, "an object", toObject)
+}
+
+// ToSlice 将数组转换为 []T，基础类型沿用 GetInt 等方法的转换规则，其他类型先尝试类型断言再通过 JSON 转换
+func ToSlice[T any](ja *JsonArray) ([]T, error) {
+	if _, ok := any([]T(nil)).([]*JsonObject); ok {
+		objs, err := ja.ToObjectSlice()
+		return any(objs).([]T), err
+	}
+	convert, kind := coercerFor[T]()
+	return convertSlice(ja, kind, convert)
+}
+
+func convertSlice[T any](ja *JsonArray, kind string, convert coercer[T]) ([]T, error) {
+	ja.mu.RLock()
+	defer ja.mu.RUnlock()
+
+	policy := ja.coercionPolicy()
+	res := make([]T, len(ja.data))
+	var errs []error
+	for i, val := range ja.data {
+		val = resolve(val)
+		converted, err := convert(val, policy)
+		if err != nil {
+			errs = append(errs, indexTypeMismatch(i, kind, val, err))
+			continue
+		}
+		res[i] = converted
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return res, nil
+}
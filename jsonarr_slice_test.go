@@ -0,0 +1,83 @@
+package zjson
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJsonArray_ToTypedSlices(t *testing.T) {
+	arr, err := ParseToArray(`[1,"2",3.7]`)
+	assert.NoError(t, err)
+
+	ints, err := arr.ToIntSlice()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, ints)
+
+	int64s, err := arr.ToInt64Slice()
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 3}, int64s)
+
+	floats, err := arr.ToFloatSlice()
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{1, 2, 3.7}, floats)
+
+	strs, err := arr.ToStringSlice()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2", "3.7"}, strs)
+
+	bools, err := arrayOf(true, "FALSE").ToBoolSlice()
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false}, bools)
+}
+
+func TestJsonArray_ToSliceErrors(t *testing.T) {
+	arr := arrayOf(1, "x", 2, true)
+
+	ints, err := arr.ToIntSlice()
+	assert.Nil(t, ints)
+	assert.True(t, errors.Is(err, ErrTypeMismatch))
+	assert.Contains(t, err.Error(), "value at index 1 is not an integer")
+	assert.Contains(t, err.Error(), "value at index 3 is not an integer")
+}
+
+func TestJsonArray_ToObjectSlice(t *testing.T) {
+	arr, err := ParseToArray(`[{"id":1},{"id":2}]`)
+	assert.NoError(t, err)
+
+	objs, err := arr.ToObjectSlice()
+	assert.NoError(t, err)
+	assert.Len(t, objs, 2)
+	objs[1].Put("id", 3)
+	assert.Equal(t, 3, arr.GetJsonObjectIgnoreError(1).GetIntIgnoreError("id"))
+
+	arr.Add(1)
+	_, err = arr.ToObjectSlice()
+	assert.Error(t, err)
+}
+
+func TestToSlice(t *testing.T) {
+	arr, err := ParseToArray(`[{"name":"Alice"},{"name":"Bob"}]`)
+	assert.NoError(t, err)
+
+	type user struct {
+		Name string `json:"name"`
+	}
+	users, err := ToSlice[user](arr)
+	assert.NoError(t, err)
+	assert.Equal(t, []user{{"Alice"}, {"Bob"}}, users)
+
+	ints, err := ToSlice[int](arrayOf(1, "2"))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, ints)
+
+	_, err = ToSlice[int](arr)
+	assert.Error(t, err)
+}
+
+func arrayOf(values ...any) *JsonArray {
+	arr := NewJsonArray()
+	arr.AddAll(values...)
+	return arr
+}
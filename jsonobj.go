@@ -1,43 +1,70 @@
 package zjson
 
 import (
-	"errors"
+	"bytes"
+	"encoding/json"
 	"fmt"
-	"strconv"
+	"slices"
 	"sync"
-)
-
-var (
-	errKeyNotExist = errors.New("key does not exist")
-	errValueType   = errors.New("value type mismatch")
+	"time"
 )
 
 type JsonObject struct {
-	data map[string]any
-	mu   sync.RWMutex // 添加互斥锁以支持并发安全
+	data   map[string]any
+	mu     sync.RWMutex // 添加互斥锁以支持并发安全
+	policy CoercionPolicy
+	cfg    *Config
+	// keys 只在 KeysInsertion 模式下维护，记录键的插入顺序
+	keys []string
 }
 
 func ParseToJsonObject(v any) (*JsonObject, error) {
+	return parseObject(v, nil)
+}
+
+func parseObject(v any, cfg *Config) (*JsonObject, error) {
 	var strB []byte
-	if strP, ok := getPointVal[JsonObject](v); ok {
-		return strP, nil
+	if obj, ok := v.(*JsonObject); ok {
+		return obj, nil
+	} else if obj, ok := copyObjectValue(v); ok {
+		return obj, nil
 	} else if strP, ok := getPointVal[[]byte](v); ok {
 		strB = *strP
 	} else if strP, ok := getPointVal[string](v); ok {
 		strB = []byte(*strP)
-	} else if strByte, err := jsonParser.AnyToJsonString(v); err == nil {
-		return ParseToJsonObject(string(strByte))
+	} else if strByte, err := cfg.parser().AnyToJsonString(v); err == nil {
+		return parseObject(string(strByte), cfg)
 	} else {
-		return nil, fmt.Errorf("failed to parse value: %w", err)
+		return nil, fmt.Errorf("%w: cannot encode value: %w", ErrParse, err)
+	}
+
+	if cfg.builtin() {
+		if err := cfg.checkInput(strB); err != nil {
+			return nil, err
+		}
+		val, err := newDecoder(strB, cfg).decode()
+		if err != nil {
+			return nil, err
+		}
+		switch v := val.(type) {
+		case *JsonObject:
+			return v, nil
+		case map[string]any:
+			return &JsonObject{data: v, cfg: cfg}, nil
+		case nil:
+			return &JsonObject{data: make(map[string]any), cfg: cfg}, nil
+		}
+		return nil, fmt.Errorf("%w: expected an object, got %s", ErrParse, typeName(val))
 	}
 
 	var mapVal = make(map[string]any)
-	if err := jsonParser.JsonStringToAny(strB, &mapVal); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	if err := cfg.parser().JsonStringToAny(strB, &mapVal); err != nil {
+		return nil, parseFailed(strB, err)
 	}
 
 	return &JsonObject{
 		data: mapVal,
+		cfg:  cfg,
 	}, nil
 }
 
@@ -47,17 +74,53 @@ func NewJsonObject() *JsonObject {
 	}
 }
 
+// newObjectView 将原生子节点包装为共享底层数据的视图，继承父节点的设置；
+// 原生 map 没有插入顺序，KeysInsertion 模式下按键排序初始化 keys
+func newObjectView(raw map[string]any, cfg *Config, policy CoercionPolicy) *JsonObject {
+	view := &JsonObject{data: raw, cfg: cfg, policy: policy}
+	if cfg.ordered() {
+		view.keys = sortedKeys(raw)
+	}
+	return view
+}
+
+func newArrayView(raw []any, cfg *Config, policy CoercionPolicy) *JsonArray {
+	return &JsonArray{data: raw, cfg: cfg, policy: policy}
+}
+
+// attachView 将原生 map 和切片包装为视图，其他值原样返回，第二个返回值表示是否新建了视图
+func attachView(val any, cfg *Config, policy CoercionPolicy) (any, bool) {
+	switch raw := val.(type) {
+	case map[string]any:
+		return newObjectView(raw, cfg, policy), true
+	case []any:
+		return newArrayView(raw, cfg, policy), true
+	}
+	return val, false
+}
+
 func (jo *JsonObject) Put(key string, value any) {
 	jo.mu.Lock()
 	defer jo.mu.Unlock()
+	jo.set(key, value)
+}
+
+// set 写入键值并维护插入顺序，调用方需持有写锁
+func (jo *JsonObject) set(key string, value any) {
+	if _, exists := jo.data[key]; !exists && jo.cfg.ordered() {
+		jo.keys = append(jo.keys, key)
+	}
 	jo.data[key] = value
 }
 
+// Get 返回原生值，对象和数组统一为 map[string]any 和 []any，可能与容器共享数据，不应修改。
+// 之后通过 GetJsonObject、GetJsonArray 得到的视图会在视图自己的锁下原地修改同一份数据，
+// 需要与这些修改并发读取时，应使用 GetJsonObjectClone、GetJsonArrayClone 或 DeepClone 取得独立副本
 func (jo *JsonObject) Get(key string) any {
 	jo.mu.RLock()
 	defer jo.mu.RUnlock()
 	if value, exists := jo.data[key]; exists {
-		return value
+		return plainValue(value)
 	}
 	return nil
 }
@@ -65,6 +128,9 @@ func (jo *JsonObject) Get(key string) any {
 func (jo *JsonObject) Remove(key string) {
 	jo.mu.Lock()
 	defer jo.mu.Unlock()
+	if _, exists := jo.data[key]; exists && jo.cfg.ordered() {
+		jo.keys = slices.DeleteFunc(jo.keys, func(k string) bool { return k == key })
+	}
 	delete(jo.data, key)
 }
 
@@ -82,46 +148,72 @@ func (jo *JsonObject) Length() int {
 }
 
 func (jo *JsonObject) ToJsonStr() string {
-	jo.mu.RLock()
-	defer jo.mu.RUnlock()
-	jsonStr, err := jsonParser.AnyToJsonString(jo.data)
+	jsonStr, err := jo.MarshalJSON()
 	if err != nil {
 		return "{}"
 	}
 	return string(jsonStr)
 }
 
-func (jo *JsonObject) ToStruct(s any) error {
+func (jo *JsonObject) MarshalJSON() ([]byte, error) {
 	jo.mu.RLock()
 	defer jo.mu.RUnlock()
-	if err := jsonParser.JsonStringToAny([]byte(jo.ToJsonStr()), s); err != nil {
-		return fmt.Errorf("failed to convert to struct: %w", err)
+	if jo.cfg.ordered() {
+		return jo.marshalOrdered()
 	}
-	return nil
+	return jo.cfg.encode(jo.data)
 }
 
-func (jo *JsonObject) GetInt(key string) (int, error) {
+// marshalOrdered 按插入顺序输出键，调用方需持有锁
+func (jo *JsonObject) marshalOrdered() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range jo.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		keyB, err := jo.cfg.encode(key)
+		if err != nil {
+			return nil, err
+		}
+		valB, err := jo.cfg.encode(jo.data[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(keyB)
+		buf.WriteByte(':')
+		buf.Write(valB)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// snapshot 返回当前数据的浅拷贝，延迟解析的节点会被展开
+func (jo *JsonObject) snapshot() map[string]any {
 	jo.mu.RLock()
 	defer jo.mu.RUnlock()
+	res := make(map[string]any, len(jo.data))
+	for k, v := range jo.data {
+		res[k] = resolve(v)
+	}
+	return res
+}
 
-	val, exist := jo.data[key]
-	if !exist {
-		return 0, fmt.Errorf("%w: key '%s'", errKeyNotExist, key)
-	}
-
-	switch v := val.(type) {
-	case int:
-		return v, nil
-	case int64:
-		return int(v), nil
-	case float64:
-		return int(v), nil
-	case string:
-		if number, err := strconv.ParseInt(v, 10, 64); err == nil {
-			return int(number), nil
-		}
+func (jo *JsonObject) ToStruct(s any) error {
+	if err := jo.cfg.parser().JsonStringToAny([]byte(jo.ToJsonStr()), s); err != nil {
+		return fmt.Errorf("failed to convert to struct: %w", err)
 	}
-	return 0, fmt.Errorf("%w: key '%s' is not an integer", errValueType, key)
+	return nil
+}
+
+// value 读取键对应的值并展开延迟解析的节点，调用方需持有锁
+func (jo *JsonObject) value(key string) (any, bool) {
+	val, exist := jo.data[key]
+	return resolve(val), exist
+}
+
+func (jo *JsonObject) GetInt(key string) (int, error) {
+	return getByKey(jo, key, "an integer", toInt)
 }
 
 func (jo *JsonObject) GetIntIgnoreError(key string) int {
@@ -130,27 +222,7 @@ func (jo *JsonObject) GetIntIgnoreError(key string) int {
 }
 
 func (jo *JsonObject) GetFloat(key string) (float64, error) {
-	jo.mu.RLock()
-	defer jo.mu.RUnlock()
-
-	val, exist := jo.data[key]
-	if !exist {
-		return 0, fmt.Errorf("%w: key '%s'", errKeyNotExist, key)
-	}
-
-	switch v := val.(type) {
-	case float64:
-		return v, nil
-	case int:
-		return float64(v), nil
-	case int64:
-		return float64(v), nil
-	case string:
-		if number, err := strconv.ParseFloat(v, 64); err == nil {
-			return number, nil
-		}
-	}
-	return 0, fmt.Errorf("%w: key '%s' is not a float", errValueType, key)
+	return getByKey(jo, key, "a float", toFloat)
 }
 
 func (jo *JsonObject) GetFloatIgnoreError(key string) float64 {
@@ -159,18 +231,7 @@ func (jo *JsonObject) GetFloatIgnoreError(key string) float64 {
 }
 
 func (jo *JsonObject) GetString(key string) (string, error) {
-	jo.mu.RLock()
-	defer jo.mu.RUnlock()
-
-	val, exist := jo.data[key]
-	if !exist {
-		return "", fmt.Errorf("%w: key '%s'", errKeyNotExist, key)
-	}
-
-	if strVal, ok := val.(string); ok {
-		return strVal, nil
-	}
-	return fmt.Sprint(val), nil
+	return getByKey(jo, key, "a string", toString)
 }
 
 func (jo *JsonObject) GetStringIgnoreError(key string) string {
@@ -179,43 +240,121 @@ func (jo *JsonObject) GetStringIgnoreError(key string) string {
 }
 
 func (jo *JsonObject) GetBool(key string) (bool, error) {
+	return getByKey(jo, key, "a boolean", toBool)
+}
+
+func (jo *JsonObject) GetBoolIgnoreError(key string) bool {
+	val, _ := jo.GetBool(key)
+	return val
+}
+
+func (jo *JsonObject) GetInt64(key string) (int64, error) {
+	return getByKey(jo, key, "an integer", toInt64)
+}
+
+func (jo *JsonObject) GetInt64IgnoreError(key string) int64 {
+	val, _ := jo.GetInt64(key)
+	return val
+}
+
+func (jo *JsonObject) GetInt32(key string) (int32, error) {
+	return getByKey(jo, key, "a 32-bit integer", toInt32)
+}
+
+func (jo *JsonObject) GetInt32IgnoreError(key string) int32 {
+	val, _ := jo.GetInt32(key)
+	return val
+}
+
+func (jo *JsonObject) GetUint(key string) (uint, error) {
+	return getByKey(jo, key, "an unsigned integer", toUint)
+}
+
+func (jo *JsonObject) GetUintIgnoreError(key string) uint {
+	val, _ := jo.GetUint(key)
+	return val
+}
+
+func (jo *JsonObject) GetTime(key string) (time.Time, error) {
+	return getByKey(jo, key, "a time", toTime)
+}
+
+func (jo *JsonObject) GetTimeIgnoreError(key string) time.Time {
+	val, _ := jo.GetTime(key)
+	return val
+}
+
+func (jo *JsonObject) GetDuration(key string) (time.Duration, error) {
+	return getByKey(jo, key, "a duration", toDuration)
+}
+
+func (jo *JsonObject) GetDurationIgnoreError(key string) time.Duration {
+	val, _ := jo.GetDuration(key)
+	return val
+}
+
+func (jo *JsonObject) GetBytes(key string) ([]byte, error) {
+	return getByKey(jo, key, "base64 encoded bytes", toBytes)
+}
+
+func (jo *JsonObject) GetBytesIgnoreError(key string) []byte {
+	val, _ := jo.GetBytes(key)
+	return val
+}
+
+// GetRaw 返回值的 JSON 文本，延迟解析且未访问过的节点直接返回原始字节
+func (jo *JsonObject) GetRaw(key string) (json.RawMessage, error) {
 	jo.mu.RLock()
 	defer jo.mu.RUnlock()
 
 	val, exist := jo.data[key]
 	if !exist {
-		return false, fmt.Errorf("%w: key '%s'", errKeyNotExist, key)
-	}
-
-	switch v := val.(type) {
-	case bool:
-		return v, nil
-	case string:
-		switch v {
-		case "true", "True", "TRUE":
-			return true, nil
-		case "false", "False", "FALSE":
-			return false, nil
-		}
+		return nil, keyNotFound(key)
 	}
-	return false, fmt.Errorf("%w: key '%s' is not a boolean", errValueType, key)
+	raw, err := marshalRaw(val)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal key '%s': %w", key, err)
+	}
+	return raw, nil
 }
 
-func (jo *JsonObject) GetBoolIgnoreError(key string) bool {
-	val, _ := jo.GetBool(key)
-	return val
+func (jo *JsonObject) GetJsonObject(key string) (*JsonObject, error) {
+	val, err := jo.child(key)
+	if err != nil {
+		return nil, err
+	}
+	return convertObject(val, jo.cfg, func(reason error) error { return keyTypeMismatch(key, "an object", val, reason) })
 }
 
-func (jo *JsonObject) GetJsonObject(key string) (*JsonObject, error) {
+// child 读取键对应的值，原生 map 和切片包装为共享底层数据的视图并写回，之后的访问都返回同一实例
+func (jo *JsonObject) child(key string) (any, error) {
 	jo.mu.RLock()
-	defer jo.mu.RUnlock()
-
 	val, exist := jo.data[key]
+	jo.mu.RUnlock()
 	if !exist {
-		return nil, fmt.Errorf("%w: key '%s'", errKeyNotExist, key)
+		return nil, keyNotFound(key)
+	}
+	val, err := resolveErr(val)
+	if err != nil {
+		return nil, err
+	}
+	switch val.(type) {
+	case map[string]any, []any:
+	default:
+		return val, nil
 	}
 
-	return ParseToJsonObject(val)
+	jo.mu.Lock()
+	defer jo.mu.Unlock()
+	val, exist = jo.value(key)
+	if !exist {
+		return nil, keyNotFound(key)
+	}
+	if view, attached := attachView(val, jo.cfg, jo.policy); attached {
+		jo.data[key] = view
+		return view, nil
+	}
+	return val, nil
 }
 
 func (jo *JsonObject) GetJsonObjectIgnoreError(key string) *JsonObject {
@@ -223,19 +362,31 @@ func (jo *JsonObject) GetJsonObjectIgnoreError(key string) *JsonObject {
 	return val
 }
 
-func (jo *JsonObject) GetJsonArray(key string) (*JsonArray, error) {
-	jo.mu.RLock()
-	defer jo.mu.RUnlock()
-
-	val, exist := jo.data[key]
-	if !exist {
-		return nil, fmt.Errorf("%w: key '%s'", errKeyNotExist, key)
+func (jo *JsonObject) GetJsonObjectClone(key string) (*JsonObject, error) {
+	val, err := jo.GetJsonObject(key)
+	if err != nil {
+		return nil, err
 	}
+	return val.DeepClone(), nil
+}
 
-	return ParseToArray(val)
+func (jo *JsonObject) GetJsonArray(key string) (*JsonArray, error) {
+	val, err := jo.child(key)
+	if err != nil {
+		return nil, err
+	}
+	return convertArray(val, jo.cfg, func(reason error) error { return keyTypeMismatch(key, "an array", val, reason) })
 }
 
 func (jo *JsonObject) GetJsonArrayIgnoreError(key string) *JsonArray {
 	val, _ := jo.GetJsonArray(key)
 	return val
 }
+
+func (jo *JsonObject) GetJsonArrayClone(key string) (*JsonArray, error) {
+	val, err := jo.GetJsonArray(key)
+	if err != nil {
+		return nil, err
+	}
+	return val.DeepClone(), nil
+}
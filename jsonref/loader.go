@@ -0,0 +1,49 @@
+// Package jsonref 解析 JsonObject 中跨文档的 JSON Reference（{"$ref": "other.json#/defs/x"}），
+// 可以展开为不含引用的树，也可以打包为单个文档
+package jsonref
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Loader 按 URI（不含片段）读取被引用的文档
+type Loader interface {
+	Load(uri string) ([]byte, error)
+}
+
+type LoaderFunc func(uri string) ([]byte, error)
+
+func (f LoaderFunc) Load(uri string) ([]byte, error) {
+	return f(uri)
+}
+
+// Dir 从操作系统的目录读取，URI 为相对 dir 的斜杠路径；
+// 只能读取 dir 内的文件，绝对路径、越出 dir 的路径和指向 dir 外的符号链接都会被拒绝
+func Dir(dir string) Loader {
+	return LoaderFunc(func(uri string) ([]byte, error) {
+		if !fs.ValidPath(uri) {
+			return nil, &fs.PathError{Op: "open", Path: uri, Err: fs.ErrInvalid}
+		}
+		root, err := os.OpenRoot(dir)
+		if err != nil {
+			return nil, err
+		}
+		defer root.Close()
+		file, err := root.Open(filepath.FromSlash(uri))
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		return io.ReadAll(file)
+	})
+}
+
+// FS 从 fs.FS 读取，URI 需为 fs.ValidPath 接受的路径
+func FS(fsys fs.FS) Loader {
+	return LoaderFunc(func(uri string) ([]byte, error) {
+		return fs.ReadFile(fsys, uri)
+	})
+}
@@ -0,0 +1,102 @@
+package jsonref
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/codinglz/zjson"
+)
+
+var ErrInvalidPointer = errors.New("invalid JSON pointer")
+
+// splitPointer 按 RFC 6901 拆分 JSON Pointer，空字符串表示整个文档
+func splitPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: '%s' must start with '/'", ErrInvalidPointer, pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		for j := 0; j < len(token); j++ {
+			if token[j] == '~' && (j+1 == len(token) || (token[j+1] != '0' && token[j+1] != '1')) {
+				return nil, fmt.Errorf("%w: '%s' has an invalid escape", ErrInvalidPointer, pointer)
+			}
+		}
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens, nil
+}
+
+func escapeToken(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// pointerGet 读取 pointer 指向的值，不存在时返回 zjson.ErrKeyNotFound 或 zjson.ErrIndexOutOfRange
+func pointerGet(doc any, pointer string) (any, error) {
+	tokens, err := splitPointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	val := doc
+	for i, token := range tokens {
+		at := "/" + strings.Join(escapeTokens(tokens[:i+1]), "/")
+		switch v := val.(type) {
+		case map[string]any:
+			item, exist := v[token]
+			if !exist {
+				return nil, fmt.Errorf("%w: '%s'", zjson.ErrKeyNotFound, at)
+			}
+			val = item
+		case []any:
+			index, err := strconv.Atoi(token)
+			if err != nil || (len(token) > 1 && token[0] == '0') || token[0] == '-' || token[0] == '+' {
+				return nil, fmt.Errorf("%w: '%s' is not an array index", ErrInvalidPointer, at)
+			}
+			if index >= len(v) {
+				return nil, fmt.Errorf("%w: '%s'", zjson.ErrIndexOutOfRange, at)
+			}
+			val = v[index]
+		default:
+			return nil, fmt.Errorf("%w: '%s'", zjson.ErrKeyNotFound, at)
+		}
+	}
+	return val, nil
+}
+
+// pointerSet 写入 pointer 指向的位置，缺少的中间对象会被创建
+func pointerSet(doc map[string]any, pointer string, val any) error {
+	tokens, err := splitPointer(pointer)
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		return fmt.Errorf("%w: cannot replace the whole document", ErrInvalidPointer)
+	}
+	parent := doc
+	for i, token := range tokens[:len(tokens)-1] {
+		child, exist := parent[token]
+		if !exist {
+			child = make(map[string]any)
+			parent[token] = child
+		}
+		next, ok := child.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%w: '/%s' is not an object", ErrInvalidPointer, strings.Join(escapeTokens(tokens[:i+1]), "/"))
+		}
+		parent = next
+	}
+	parent[tokens[len(tokens)-1]] = val
+	return nil
+}
+
+func escapeTokens(tokens []string) []string {
+	res := make([]string, len(tokens))
+	for i, token := range tokens {
+		res[i] = escapeToken(token)
+	}
+	return res
+}
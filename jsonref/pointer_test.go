@@ -0,0 +1,71 @@
+package jsonref
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/codinglz/zjson"
+	"github.com/stretchr/testify/assert"
+)
+
+// 测试 RFC 6901 中的示例
+func TestPointerGet(t *testing.T) {
+	var doc any
+	assert.Nil(t, json.Unmarshal([]byte(`{
+		"foo": ["bar", "baz"], "": 0, "a/b": 1, "c%d": 2, "e^f": 3,
+		"g|h": 4, "i\\j": 5, "k\"l": 6, " ": 7, "m~n": 8
+	}`), &doc))
+
+	cases := map[string]any{
+		"/foo":   []any{"bar", "baz"},
+		"/foo/0": "bar",
+		"/":      0.0,
+		"/a~1b":  1.0,
+		"/c%d":   2.0,
+		"/e^f":   3.0,
+		"/g|h":   4.0,
+		"/i\\j":  5.0,
+		"/k\"l":  6.0,
+		"/ ":     7.0,
+		"/m~0n":  8.0,
+	}
+	for pointer, expected := range cases {
+		val, err := pointerGet(doc, pointer)
+		assert.Nil(t, err, pointer)
+		assert.Equal(t, expected, val, pointer)
+	}
+	val, err := pointerGet(doc, "")
+	assert.Nil(t, err)
+	assert.Equal(t, doc, val)
+}
+
+// 测试非法指针和不存在的位置
+func TestPointerGet_Errors(t *testing.T) {
+	doc := map[string]any{"a": []any{1.0, map[string]any{"b": true}}}
+
+	_, err := pointerGet(doc, "a")
+	assert.ErrorIs(t, err, ErrInvalidPointer)
+	_, err = pointerGet(doc, "/a~2")
+	assert.ErrorIs(t, err, ErrInvalidPointer)
+	_, err = pointerGet(doc, "/a/01")
+	assert.ErrorIs(t, err, ErrInvalidPointer)
+	_, err = pointerGet(doc, "/a/-")
+	assert.ErrorIs(t, err, ErrInvalidPointer)
+
+	_, err = pointerGet(doc, "/a/2")
+	assert.ErrorIs(t, err, zjson.ErrIndexOutOfRange)
+	_, err = pointerGet(doc, "/a/1/c")
+	assert.ErrorIs(t, err, zjson.ErrKeyNotFound)
+	assert.EqualError(t, err, "key does not exist: '/a/1/c'")
+	_, err = pointerGet(doc, "/a/0/b")
+	assert.ErrorIs(t, err, zjson.ErrKeyNotFound)
+}
+
+// 测试写入时创建中间对象
+func TestPointerSet(t *testing.T) {
+	doc := map[string]any{"a": 1.0}
+	assert.Nil(t, pointerSet(doc, "/components/schemas/x~1y", true))
+	assert.Equal(t, map[string]any{"a": 1.0, "components": map[string]any{"schemas": map[string]any{"x/y": true}}}, doc)
+	assert.ErrorIs(t, pointerSet(doc, "/a/b", true), ErrInvalidPointer)
+	assert.ErrorIs(t, pointerSet(doc, "", true), ErrInvalidPointer)
+}
@@ -0,0 +1,398 @@
+package jsonref
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/codinglz/zjson"
+)
+
+var ErrCircularRef = errors.New("circular reference")
+
+const defaultDefsPointer = "/$defs"
+
+// RefError 描述无法解析的引用，Doc 和 At 为 $ref 节点所在的文档和 JSON Pointer
+type RefError struct {
+	Ref string
+	Doc string
+	At  string
+	Err error
+}
+
+func (e *RefError) Error() string {
+	return fmt.Sprintf("jsonref: resolve '%s' at %s#%s: %v", e.Ref, e.Doc, e.At, e.Err)
+}
+
+func (e *RefError) Unwrap() error {
+	return e.Err
+}
+
+// Resolver 通过 Loader 读取被引用的文档，相对引用以所在文档的 URI 为基准，
+// 同一次调用中每个文档只读取一次
+type Resolver struct {
+	// DefsPointer 为 Bundle 存放外部内容的位置，默认为 "/$defs"，OpenAPI 文档可以使用 "/components/schemas"
+	DefsPointer string
+	loader      Loader
+}
+
+// NewResolver 创建 Resolver，loader 为 nil 时只能解析文档内的引用
+func NewResolver(loader Loader) *Resolver {
+	return &Resolver{loader: loader}
+}
+
+// Dereference 返回把所有 $ref 替换为被引用内容的副本，base 为 obj 所在文档的 URI。
+// $ref 节点的其他键会覆盖被引用对象中的同名键，循环引用无法展开，返回 ErrCircularRef
+func (r *Resolver) Dereference(obj *zjson.JsonObject, base string) (*zjson.JsonObject, error) {
+	s, root, err := r.start(obj, base)
+	if err != nil {
+		return nil, err
+	}
+	d := &dereferencer{state: s, done: make(map[string]any), active: make(map[string]bool)}
+	res, err := d.walk(root, base, "")
+	if err != nil {
+		return nil, err
+	}
+	return finish(obj, res)
+}
+
+// Bundle 返回单个文档：外部文档中被引用的内容复制到 DefsPointer 下，引用改写为文档内引用。
+// 文档内引用保持不变，因此循环引用同样可以打包
+func (r *Resolver) Bundle(obj *zjson.JsonObject, base string) (*zjson.JsonObject, error) {
+	s, root, err := r.start(obj, base)
+	if err != nil {
+		return nil, err
+	}
+	defsPointer := r.DefsPointer
+	if defsPointer == "" {
+		defsPointer = defaultDefsPointer
+	}
+	b := &bundler{
+		state:       s,
+		base:        base,
+		defsPointer: defsPointer,
+		names:       make(map[string]string),
+		defs:        make(map[string]any),
+		used:        make(map[string]bool),
+	}
+	if existing, err := pointerGet(root, defsPointer); err == nil {
+		if m, ok := existing.(map[string]any); ok {
+			for name := range m {
+				b.used[name] = true
+			}
+		}
+	}
+
+	res, err := b.walk(root, base, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(b.defs) > 0 {
+		doc := res.(map[string]any)
+		defs, _ := pointerGet(doc, defsPointer)
+		merged, _ := defs.(map[string]any)
+		if merged == nil {
+			merged = make(map[string]any, len(b.defs))
+		}
+		for name, val := range b.defs {
+			merged[name] = val
+		}
+		if err := pointerSet(doc, defsPointer, merged); err != nil {
+			return nil, err
+		}
+	}
+	return finish(obj, res)
+}
+
+type state struct {
+	loader Loader
+	docs   map[string]any
+}
+
+func (r *Resolver) start(obj *zjson.JsonObject, base string) (*state, any, error) {
+	raw, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, nil, err
+	}
+	root, err := decode(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &state{loader: r.loader, docs: map[string]any{base: root}}, root, nil
+}
+
+// finish 按 obj 的 Config 重新解析结果
+func finish(obj *zjson.JsonObject, res any) (*zjson.JsonObject, error) {
+	raw, err := json.Marshal(res)
+	if err != nil {
+		return nil, err
+	}
+	return obj.Config().ParseToJsonObject(raw)
+}
+
+// decode 使用 json.Number 保留数字的原始文本
+func decode(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var val any
+	if err := dec.Decode(&val); err != nil {
+		return nil, fmt.Errorf("%w: %w", zjson.ErrParse, err)
+	}
+	return val, nil
+}
+
+func (s *state) document(uri string) (any, error) {
+	if doc, ok := s.docs[uri]; ok {
+		return doc, nil
+	}
+	if s.loader == nil {
+		return nil, fmt.Errorf("no loader for document '%s'", uri)
+	}
+	data, err := s.loader.Load(uri)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("document '%s': %w", uri, err)
+	}
+	s.docs[uri] = doc
+	return doc, nil
+}
+
+// target 返回引用指向的文档 URI、片段和被引用的值
+func (s *state) target(doc, ref string) (string, string, any, error) {
+	uri, fragment, err := resolveURI(doc, ref)
+	if err != nil {
+		return "", "", nil, err
+	}
+	root, err := s.document(uri)
+	if err != nil {
+		return "", "", nil, err
+	}
+	val, err := pointerGet(root, fragment)
+	if err != nil {
+		return "", "", nil, err
+	}
+	return uri, fragment, val, nil
+}
+
+// resolveURI 以 base 为基准解析 ref，没有协议的 URI 按斜杠路径处理
+func resolveURI(base, ref string) (string, string, error) {
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return "", "", err
+	}
+	fragment := refURL.Fragment
+	refURL.Fragment, refURL.RawFragment = "", ""
+	switch {
+	case refURL.String() == "":
+		return base, fragment, nil
+	case refURL.Scheme != "":
+		return refURL.String(), fragment, nil
+	}
+
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", "", err
+	}
+	if baseURL.Scheme != "" {
+		return baseURL.ResolveReference(refURL).String(), fragment, nil
+	}
+	if path.IsAbs(refURL.Path) {
+		return path.Clean(refURL.Path), fragment, nil
+	}
+	return path.Join(path.Dir(base), refURL.Path), fragment, nil
+}
+
+func refOf(node map[string]any) (string, bool) {
+	ref, ok := node["$ref"].(string)
+	return ref, ok
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+type dereferencer struct {
+	*state
+	// done 缓存已展开的目标，展开结果只读，可以在多处共享；active 为正在展开的目标
+	done   map[string]any
+	active map[string]bool
+	chain  []string
+}
+
+func (d *dereferencer) walk(val any, doc, at string) (any, error) {
+	switch v := val.(type) {
+	case map[string]any:
+		if ref, ok := refOf(v); ok {
+			return d.ref(v, ref, doc, at)
+		}
+		res := make(map[string]any, len(v))
+		for _, key := range sortedKeys(v) {
+			child, err := d.walk(v[key], doc, at+"/"+escapeToken(key))
+			if err != nil {
+				return nil, err
+			}
+			res[key] = child
+		}
+		return res, nil
+	case []any:
+		res := make([]any, len(v))
+		for i, item := range v {
+			child, err := d.walk(item, doc, fmt.Sprintf("%s/%d", at, i))
+			if err != nil {
+				return nil, err
+			}
+			res[i] = child
+		}
+		return res, nil
+	}
+	return val, nil
+}
+
+func (d *dereferencer) ref(node map[string]any, ref, doc, at string) (any, error) {
+	uri, fragment, target, err := d.target(doc, ref)
+	if err != nil {
+		return nil, &RefError{Ref: ref, Doc: doc, At: at, Err: err}
+	}
+	key := uri + "#" + fragment
+
+	resolved, ok := d.done[key]
+	if !ok {
+		if d.active[key] {
+			start := 0
+			for i, k := range d.chain {
+				if k == key {
+					start = i
+				}
+			}
+			cycle := append(d.chain[start:len(d.chain):len(d.chain)], key)
+			return nil, &RefError{Ref: ref, Doc: doc, At: at, Err: fmt.Errorf("%w: %s", ErrCircularRef, strings.Join(cycle, " -> "))}
+		}
+		d.active[key] = true
+		d.chain = append(d.chain, key)
+		resolved, err = d.walk(target, uri, fragment)
+		delete(d.active, key)
+		d.chain = d.chain[:len(d.chain)-1]
+		if err != nil {
+			return nil, err
+		}
+		d.done[key] = resolved
+	}
+
+	if len(node) == 1 {
+		return resolved, nil
+	}
+	obj, ok := resolved.(map[string]any)
+	if !ok {
+		return resolved, nil
+	}
+	res := make(map[string]any, len(obj)+len(node)-1)
+	for k, v := range obj {
+		res[k] = v
+	}
+	for _, k := range sortedKeys(node) {
+		if k == "$ref" {
+			continue
+		}
+		child, err := d.walk(node[k], doc, at+"/"+escapeToken(k))
+		if err != nil {
+			return nil, err
+		}
+		res[k] = child
+	}
+	return res, nil
+}
+
+type bundler struct {
+	*state
+	base        string
+	defsPointer string
+	// names 记录外部目标对应的定义名，defs 为新增的定义，used 为已占用的定义名
+	names map[string]string
+	defs  map[string]any
+	used  map[string]bool
+}
+
+func (b *bundler) walk(val any, doc, at string) (any, error) {
+	switch v := val.(type) {
+	case map[string]any:
+		res := make(map[string]any, len(v))
+		for _, key := range sortedKeys(v) {
+			child, err := b.walk(v[key], doc, at+"/"+escapeToken(key))
+			if err != nil {
+				return nil, err
+			}
+			res[key] = child
+		}
+		if ref, ok := refOf(v); ok {
+			local, err := b.ref(ref, doc, at)
+			if err != nil {
+				return nil, err
+			}
+			res["$ref"] = local
+		}
+		return res, nil
+	case []any:
+		res := make([]any, len(v))
+		for i, item := range v {
+			child, err := b.walk(item, doc, fmt.Sprintf("%s/%d", at, i))
+			if err != nil {
+				return nil, err
+			}
+			res[i] = child
+		}
+		return res, nil
+	}
+	return val, nil
+}
+
+// ref 返回改写后的文档内引用，外部目标在第一次遇到时加入 defs
+func (b *bundler) ref(ref, doc, at string) (string, error) {
+	uri, fragment, target, err := b.target(doc, ref)
+	if err != nil {
+		return "", &RefError{Ref: ref, Doc: doc, At: at, Err: err}
+	}
+	if uri == b.base {
+		return "#" + (&url.URL{Fragment: fragment}).EscapedFragment(), nil
+	}
+
+	key := uri + "#" + fragment
+	name, ok := b.names[key]
+	if !ok {
+		name = b.newName(uri, fragment)
+		// 先登记名称再处理内容，循环引用会指向同一个定义
+		b.names[key] = name
+		bundled, err := b.walk(target, uri, fragment)
+		if err != nil {
+			return "", err
+		}
+		b.defs[name] = bundled
+	}
+	return "#" + (&url.URL{Fragment: b.defsPointer + "/" + escapeToken(name)}).EscapedFragment(), nil
+}
+
+// newName 使用片段的最后一段作为定义名，片段为空时使用文件名，重名时添加数字后缀
+func (b *bundler) newName(uri, fragment string) string {
+	base := strings.TrimSuffix(path.Base(uri), path.Ext(uri))
+	if tokens, _ := splitPointer(fragment); len(tokens) > 0 {
+		base = tokens[len(tokens)-1]
+	}
+	name := base
+	for i := 2; b.used[name]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	b.used[name] = true
+	return name
+}
@@ -0,0 +1,169 @@
+package jsonref
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/codinglz/zjson"
+	"github.com/stretchr/testify/assert"
+)
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"api/common.json": {Data: []byte(`{
+			"defs": {
+				"id": {"type": "integer", "format": "int64"},
+				"user": {"type": "object", "properties": {"id": {"$ref": "#/defs/id"}, "name": {"$ref": "types/string.json"}}}
+			}
+		}`)},
+		"api/types/string.json": {Data: []byte(`{"type": "string", "maxLength": 64}`)},
+		"api/tree.json": {Data: []byte(`{
+			"node": {"type": "object", "properties": {"children": {"type": "array", "items": {"$ref": "#/node"}}}}
+		}`)},
+	}
+}
+
+func parse(t *testing.T, s string) *zjson.JsonObject {
+	t.Helper()
+	obj, err := zjson.ParseToJsonObject(s)
+	assert.Nil(t, err)
+	return obj
+}
+
+// 测试跨文档展开，包括相对路径、文档内引用和兄弟键覆盖
+func TestResolver_Dereference(t *testing.T) {
+	doc := parse(t, `{
+		"user": {"$ref": "common.json#/defs/user"},
+		"admin": {"$ref": "common.json#/defs/user", "description": "admin user"},
+		"ids": [{"$ref": "common.json#/defs/user/properties/id"}, {"$ref": "common.json#/defs/id"}],
+		"limit": 9007199254740993
+	}`)
+	res, err := NewResolver(FS(testFS())).Dereference(doc, "api/openapi.json")
+	assert.Nil(t, err)
+
+	user := `{"properties":{"id":{"format":"int64","type":"integer"},"name":{"maxLength":64,"type":"string"}},"type":"object"}`
+	assert.JSONEq(t, user, res.GetJsonObjectIgnoreError("user").ToJsonStr())
+	assert.Equal(t, "admin user", res.GetJsonObjectIgnoreError("admin").GetStringIgnoreError("description"))
+	assert.Equal(t, `[{"format":"int64","type":"integer"},{"format":"int64","type":"integer"}]`, res.GetJsonArrayIgnoreError("ids").ToJsonStr())
+	assert.NotContains(t, res.ToJsonStr(), "$ref")
+
+	// 原对象不变
+	assert.Equal(t, "common.json#/defs/user", doc.GetJsonObjectIgnoreError("user").GetStringIgnoreError("$ref"))
+}
+
+// 测试结果保留原对象的 Config
+func TestResolver_Config(t *testing.T) {
+	doc, err := zjson.Config{Numbers: zjson.NumberInt64}.ParseToJsonObject(`{"a": {"$ref": "#/b"}, "b": 9007199254740993}`)
+	assert.Nil(t, err)
+	res, err := NewResolver(nil).Dereference(doc, "")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(9007199254740993), res.GetInt64IgnoreError("a"))
+	assert.Equal(t, zjson.NumberInt64, res.Config().Numbers)
+}
+
+// 测试循环引用在展开时报错，在打包时保留为文档内引用
+func TestResolver_Circular(t *testing.T) {
+	r := NewResolver(FS(testFS()))
+	doc := parse(t, `{"tree": {"$ref": "tree.json#/node"}}`)
+
+	_, err := r.Dereference(doc, "api/root.json")
+	assert.ErrorIs(t, err, ErrCircularRef)
+	var refErr *RefError
+	assert.True(t, errors.As(err, &refErr))
+	assert.Equal(t, "api/tree.json", refErr.Doc)
+	assert.Equal(t, "/node/properties/children/items", refErr.At)
+	assert.ErrorContains(t, err, "api/tree.json#/node -> api/tree.json#/node")
+
+	res, err := r.Bundle(doc, "api/root.json")
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"tree": {"$ref": "#/$defs/node"},
+		"$defs": {"node": {"type": "object", "properties": {"children": {"type": "array", "items": {"$ref": "#/$defs/node"}}}}}
+	}`, res.ToJsonStr())
+
+	doc = parse(t, `{"a": {"$ref": "#/b"}, "b": {"$ref": "#/a"}}`)
+	_, err = r.Dereference(doc, "")
+	assert.ErrorIs(t, err, ErrCircularRef)
+}
+
+// 测试打包为单个文档，重名定义添加后缀，已有定义保持不变
+func TestResolver_Bundle(t *testing.T) {
+	r := NewResolver(FS(testFS()))
+	r.DefsPointer = "/components/schemas"
+	doc := parse(t, `{
+		"paths": {"/users": {"schema": {"$ref": "common.json#/defs/user"}}},
+		"local": {"$ref": "#/components/schemas/id"},
+		"components": {"schemas": {"id": {"type": "string"}}}
+	}`)
+	res, err := r.Bundle(doc, "api/openapi.json")
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"paths": {"/users": {"schema": {"$ref": "#/components/schemas/user"}}},
+		"local": {"$ref": "#/components/schemas/id"},
+		"components": {"schemas": {
+			"id": {"type": "string"},
+			"user": {"type": "object", "properties": {
+				"id": {"$ref": "#/components/schemas/id_2"},
+				"name": {"$ref": "#/components/schemas/string"}
+			}},
+			"id_2": {"type": "integer", "format": "int64"},
+			"string": {"type": "string", "maxLength": 64}
+		}}
+	}`, res.ToJsonStr())
+
+	// 打包结果可以直接展开
+	deref, err := NewResolver(nil).Dereference(res, "")
+	assert.Nil(t, err)
+	assert.Equal(t, "int64", deref.GetJsonObjectIgnoreError("paths").GetJsonObjectIgnoreError("/users").
+		GetJsonObjectIgnoreError("schema").GetJsonObjectIgnoreError("properties").GetJsonObjectIgnoreError("id").GetStringIgnoreError("format"))
+}
+
+// 测试从操作系统目录读取
+func TestResolver_Dir(t *testing.T) {
+	dir := t.TempDir()
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir, "schemas"), 0o755))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "schemas", "pet.json"), []byte(`{"name": {"type": "string"}}`), 0o644))
+
+	doc := parse(t, `{"pet": {"$ref": "schemas/pet.json#/name"}}`)
+	res, err := NewResolver(Dir(dir)).Dereference(doc, "root.json")
+	assert.Nil(t, err)
+	assert.Equal(t, `{"pet":{"type":"string"}}`, res.ToJsonStr())
+}
+
+// 测试 Dir 拒绝读取目录之外的文件
+func TestResolver_DirConfined(t *testing.T) {
+	parent := t.TempDir()
+	dir := filepath.Join(parent, "schemas")
+	assert.Nil(t, os.MkdirAll(dir, 0o755))
+	secret := filepath.Join(parent, "secret.json")
+	assert.Nil(t, os.WriteFile(secret, []byte(`{"token": "x"}`), 0o644))
+	assert.Nil(t, os.Symlink(secret, filepath.Join(dir, "link.json")))
+
+	r := NewResolver(Dir(dir))
+	for _, ref := range []string{"../secret.json", "sub/../../secret.json", filepath.ToSlash(secret), "link.json"} {
+		res, err := r.Dereference(parse(t, `{"a": {"$ref": "`+ref+`"}}`), "root.json")
+		assert.Error(t, err, ref)
+		assert.Nil(t, res, ref)
+		var refErr *RefError
+		assert.True(t, errors.As(err, &refErr), ref)
+	}
+}
+
+// 测试引用错误包含 $ref 所在的位置
+func TestResolver_Errors(t *testing.T) {
+	r := NewResolver(FS(testFS()))
+
+	_, err := r.Dereference(parse(t, `{"a": [{"$ref": "missing.json"}]}`), "api/root.json")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	assert.ErrorContains(t, err, "jsonref: resolve 'missing.json' at api/root.json#/a/0")
+
+	_, err = r.Bundle(parse(t, `{"a": {"$ref": "common.json#/defs/missing"}}`), "api/root.json")
+	assert.ErrorIs(t, err, zjson.ErrKeyNotFound)
+	assert.EqualError(t, err, "jsonref: resolve 'common.json#/defs/missing' at api/root.json#/a: key does not exist: '/defs/missing'")
+
+	_, err = NewResolver(nil).Dereference(parse(t, `{"a": {"$ref": "other.json"}}`), "")
+	assert.ErrorContains(t, err, "no loader for document 'other.json'")
+}
@@ -0,0 +1,268 @@
+package zjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// lazyNode 保存尚未解析的嵌套对象或数组的原始字节，首次访问时才按 cfg 解析，
+// depth 和 path 记录节点在文档中的位置，用于检查 MaxDepth 和生成错误路径
+type lazyNode struct {
+	raw   []byte
+	cfg   *Config
+	depth int
+	path  []string
+	mu    sync.Mutex
+	val   any
+	err   error
+}
+
+func ParseToJsonObjectLazy(v any) (*JsonObject, error) {
+	return lazyObject(v, nil)
+}
+
+func ParseToArrayLazy(v any) (*JsonArray, error) {
+	return lazyArray(v, nil)
+}
+
+func lazyObject(v any, cfg *Config) (*JsonObject, error) {
+	if obj, ok := v.(*JsonObject); ok {
+		return obj, nil
+	}
+	strB, err := toJsonBytes(v, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return parseLazyObject(strB, cfg)
+}
+
+func lazyArray(v any, cfg *Config) (*JsonArray, error) {
+	if arr, ok := v.(*JsonArray); ok {
+		return arr, nil
+	}
+	strB, err := toJsonBytes(v, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return parseLazyArray(strB, cfg)
+}
+
+func toJsonBytes(v any, cfg *Config) ([]byte, error) {
+	if strP, ok := getPointVal[[]byte](v); ok {
+		return *strP, nil
+	} else if strP, ok := getPointVal[string](v); ok {
+		return []byte(*strP), nil
+	}
+	strB, err := cfg.parser().AnyToJsonString(v)
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot encode value: %w", ErrParse, err)
+	}
+	return strB, nil
+}
+
+func parseLazyObject(raw []byte, cfg *Config) (*JsonObject, error) {
+	if cfg.builtin() {
+		if err := cfg.checkInput(raw); err != nil {
+			return nil, err
+		}
+		d := newDecoder(raw, cfg)
+		d.lazyDepth = 1
+		val, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		if val == nil {
+			return &JsonObject{data: make(map[string]any), cfg: cfg}, nil
+		}
+		if obj, ok := lazyResult(val, cfg).(*JsonObject); ok {
+			return obj, nil
+		}
+		return nil, fmt.Errorf("%w: expected an object, got %s", ErrParse, typeName(val))
+	}
+
+	var fields map[string]json.RawMessage
+	if err := cfg.parser().JsonStringToAny(raw, &fields); err != nil {
+		return nil, parseFailed(raw, err)
+	}
+	if fields == nil {
+		return nil, fmt.Errorf("%w: not an object", ErrParse)
+	}
+
+	data := make(map[string]any, len(fields))
+	for key, fieldRaw := range fields {
+		val, err := newLazyValue(fieldRaw, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("%w: key '%s': %w", ErrParse, key, err)
+		}
+		data[key] = val
+	}
+	return &JsonObject{data: data, cfg: cfg}, nil
+}
+
+func parseLazyArray(raw []byte, cfg *Config) (*JsonArray, error) {
+	if cfg.builtin() {
+		if err := cfg.checkInput(raw); err != nil {
+			return nil, err
+		}
+		d := newDecoder(raw, cfg)
+		d.lazyDepth = 1
+		val, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		if val == nil {
+			return &JsonArray{data: make([]any, 0), cfg: cfg}, nil
+		}
+		if arr, ok := lazyResult(val, cfg).(*JsonArray); ok {
+			return arr, nil
+		}
+		return nil, fmt.Errorf("%w: expected an array, got %s", ErrParse, typeName(val))
+	}
+
+	var items []json.RawMessage
+	if err := cfg.parser().JsonStringToAny(raw, &items); err != nil {
+		return nil, parseFailed(raw, err)
+	}
+	if items == nil {
+		return nil, fmt.Errorf("%w: not an array", ErrParse)
+	}
+
+	data := make([]any, len(items))
+	for i, itemRaw := range items {
+		val, err := newLazyValue(itemRaw, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("%w: index %d: %w", ErrParse, i, err)
+		}
+		data[i] = val
+	}
+	return &JsonArray{data: data, cfg: cfg}, nil
+}
+
+// lazyResult 将内置解析器返回的容器包装为 JsonObject 或 JsonArray，其他值返回 nil
+func lazyResult(val any, cfg *Config) any {
+	switch v := val.(type) {
+	case *JsonObject:
+		return v
+	case map[string]any:
+		return &JsonObject{data: v, cfg: cfg}
+	case []any:
+		return &JsonArray{data: v, cfg: cfg}
+	}
+	return nil
+}
+
+// newLazyValue 标量直接解析，对象和数组保留原始字节
+func newLazyValue(raw []byte, cfg *Config) (any, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) > 0 && (raw[0] == '{' || raw[0] == '[') {
+		return &lazyNode{raw: raw, cfg: cfg}, nil
+	}
+	var val any
+	if err := cfg.parser().JsonStringToAny(raw, &val); err != nil {
+		return nil, err
+	}
+	return val, nil
+}
+
+// lazyValue 跳过嵌套的对象或数组并保留原始字节，只检查语法，其余设置在首次访问时检查
+func (d *decoder) lazyValue() (any, error) {
+	start := d.pos
+	dec := json.NewDecoder(bytes.NewReader(d.data[start:]))
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		// 由内置解析器给出带位置信息的错误
+		lazyDepth := d.lazyDepth
+		d.lazyDepth = 0
+		defer func() { d.lazyDepth = lazyDepth }()
+		var decodeErr error
+		if d.data[start] == '{' {
+			_, decodeErr = d.object()
+		} else {
+			_, decodeErr = d.array()
+		}
+		if decodeErr != nil {
+			return nil, decodeErr
+		}
+		return nil, fmt.Errorf("%w: %w", ErrParse, err)
+	}
+	d.pos = start + int(dec.InputOffset())
+	return &lazyNode{
+		raw:   bytes.Clone(d.data[start:d.pos]),
+		cfg:   d.cfg,
+		depth: d.depth,
+		path:  slices.Clone(d.path),
+	}, nil
+}
+
+// load 解析原始字节，结果和错误都会被缓存，失败的节点不会重复解析
+func (n *lazyNode) load() (any, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.val != nil || n.err != nil {
+		return n.val, n.err
+	}
+
+	if n.cfg.builtin() {
+		d := newDecoder(n.raw, n.cfg)
+		d.depth = n.depth
+		d.path = slices.Clone(n.path)
+		d.lazyDepth = n.depth + 1
+		var val any
+		if val, n.err = d.decode(); n.err == nil {
+			n.val = lazyResult(val, n.cfg)
+		}
+	} else if n.raw[0] == '{' {
+		n.val, n.err = parseLazyObject(n.raw, n.cfg)
+	} else {
+		n.val, n.err = parseLazyArray(n.raw, n.cfg)
+	}
+	if n.err != nil {
+		n.val = nil
+	}
+	return n.val, n.err
+}
+
+func (n *lazyNode) value() any {
+	val, err := n.load()
+	if err != nil {
+		// 解析失败时保留原始字节，由调用方按普通值处理
+		return json.RawMessage(n.raw)
+	}
+	return val
+}
+
+func (n *lazyNode) MarshalJSON() ([]byte, error) {
+	if n.cfg.builtin() {
+		// 重复键、字符串处理等设置会改变解析结果，先解析再序列化
+		val, err := n.load()
+		if err != nil {
+			return nil, err
+		}
+		return marshalWith(n.cfg.parser(), val)
+	}
+	n.mu.Lock()
+	val := n.val
+	n.mu.Unlock()
+	if val == nil {
+		return n.raw, nil
+	}
+	return n.cfg.parser().AnyToJsonString(val)
+}
+
+// resolveErr 与 resolve 相同，节点解析失败时返回缓存的错误
+func resolveErr(val any) (any, error) {
+	if node, ok := val.(*lazyNode); ok {
+		return node.load()
+	}
+	return val, nil
+}
+
+func resolve(val any) any {
+	if node, ok := val.(*lazyNode); ok {
+		return node.value()
+	}
+	return val
+}
@@ -0,0 +1,135 @@
+package zjson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseToJsonObjectLazy(t *testing.T) {
+	raw := `{"name":"Alice","age":30,"address":{"city":"Beijing","zip":"100000"},"tags":["a","b"]}`
+	obj, err := ParseToJsonObjectLazy(raw)
+	assert.NoError(t, err)
+
+	// 未访问的嵌套值保留原始字节
+	_, isLazy := obj.data["address"].(*lazyNode)
+	assert.True(t, isLazy)
+	assert.Equal(t, "Alice", obj.GetStringIgnoreError("name"))
+	assert.Equal(t, 30, obj.GetIntIgnoreError("age"))
+
+	address, err := obj.GetJsonObject("address")
+	assert.NoError(t, err)
+	assert.Equal(t, "Beijing", address.Get("city"))
+
+	tags, err := obj.GetJsonArray("tags")
+	assert.NoError(t, err)
+	assert.Equal(t, "b", tags.Get(1))
+
+	assert.JSONEq(t, raw, obj.ToJsonStr())
+}
+
+func TestParseToJsonObjectLazy_ReuseRawBytes(t *testing.T) {
+	obj, err := ParseToJsonObjectLazy(`{"a":{"z":1,  "y":2},"b":[3, 2, 1]}`)
+	assert.NoError(t, err)
+
+	// 未修改的子节点按原始顺序输出
+	assert.Equal(t, `{"a":{"z":1,"y":2},"b":[3,2,1]}`, obj.ToJsonStr())
+
+	a := obj.GetJsonObjectIgnoreError("a")
+	a.Put("x", 3)
+	assert.JSONEq(t, `{"a":{"z":1,"y":2,"x":3},"b":[3,2,1]}`, obj.ToJsonStr())
+}
+
+func TestParseToArrayLazy(t *testing.T) {
+	arr, err := ParseToArrayLazy(`[{"id":1},[1,2],"x",null]`)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, arr.Length())
+
+	item, err := arr.GetJsonObject(0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, item.GetIntIgnoreError("id"))
+	assert.Equal(t, "x", arr.Get(2))
+	assert.Nil(t, arr.Get(3))
+
+	_, err = ParseToArrayLazy(`{"id":1}`)
+	assert.Error(t, err)
+	_, err = ParseToJsonObjectLazy(`[1]`)
+	assert.Error(t, err)
+}
+
+// 测试延迟解析使用配置中的设置，嵌套节点在首次访问时按相同设置解析
+func TestConfig_ParseLazy(t *testing.T) {
+	cfg := Config{Numbers: NumberInt64, KeyOrder: KeysInsertion}
+	obj, err := cfg.ParseToJsonObjectLazy(`{"b":1,"a":{"z":2,"y":[3,{"x":4}]}}`)
+	assert.NoError(t, err)
+	_, isLazy := obj.data["a"].(*lazyNode)
+	assert.True(t, isLazy)
+	assert.Equal(t, int64(1), obj.Get("b"))
+
+	a := obj.GetJsonObjectIgnoreError("a")
+	assert.Equal(t, cfg, a.Config())
+	assert.Equal(t, `{"z":2,"y":[3,{"x":4}]}`, a.ToJsonStr())
+	y := a.GetJsonArrayIgnoreError("y")
+	assert.Equal(t, int64(3), y.Get(0))
+	assert.Equal(t, int64(4), y.GetJsonObjectIgnoreError(1).Get("x"))
+	assert.Equal(t, `{"b":1,"a":{"z":2,"y":[3,{"x":4}]}}`, obj.ToJsonStr())
+
+	arr, err := Config{Numbers: NumberJSON}.ParseToArrayLazy(`[1.10,[2.50]]`)
+	assert.NoError(t, err)
+	assert.Equal(t, json.Number("1.10"), arr.Get(0))
+	assert.Equal(t, json.Number("2.50"), arr.GetJsonArrayIgnoreError(1).Get(0))
+}
+
+// 测试延迟解析的嵌套节点在访问时检查限制和重复键，错误路径为完整路径
+func TestConfig_ParseLazyChecks(t *testing.T) {
+	_, err := Config{Limits: Limits{MaxInputBytes: 4}}.ParseToJsonObjectLazy(`{"a":1}`)
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+
+	obj, err := Config{Limits: Limits{MaxDepth: 2}}.ParseToJsonObjectLazy(`{"a":{"b":1},"c":{"d":{"e":1}}}`)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, obj.GetJsonObjectIgnoreError("a").GetIntIgnoreError("b"))
+	c, err := obj.GetJsonObject("c")
+	assert.NoError(t, err)
+	_, err = c.GetJsonObject("d")
+	var limitErr *LimitError
+	assert.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, "MaxDepth", limitErr.Limit)
+	assert.Equal(t, "c.d", limitErr.Path)
+
+	obj, err = Config{DuplicateKeys: DuplicateError}.ParseToJsonObjectLazy(`{"a":{"k":1,"k":2}}`)
+	assert.NoError(t, err)
+	_, err = obj.GetJsonObject("a")
+	var dupErr *DuplicateKeyError
+	assert.ErrorAs(t, err, &dupErr)
+	assert.Equal(t, "a.k", dupErr.Path)
+	_, err = obj.MarshalJSON()
+	assert.ErrorIs(t, err, ErrDuplicateKey)
+
+	obj, err = Config{DuplicateKeys: DuplicateFirstWins}.ParseToJsonObjectLazy(`{"a":{"k":1,"k":2}}`)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":{"k":1}}`, obj.ToJsonStr())
+
+	arr, err := Config{InvalidUTF8: UTF8Reject}.ParseToArrayLazy([]byte("[[\"\xff\"]]"))
+	assert.NoError(t, err)
+	_, err = arr.GetJsonArray(0)
+	assert.ErrorIs(t, err, ErrInvalidUTF8)
+
+	_, err = Config{KeyOrder: KeysInsertion}.ParseToJsonObjectLazy(`{"a":{"b":1,}}`)
+	var synErr *SyntaxError
+	assert.ErrorAs(t, err, &synErr)
+}
+
+// 测试解析失败的节点会缓存错误，之后的访问不会重新解析
+func TestLazyNode_CachesError(t *testing.T) {
+	obj, err := Config{DuplicateKeys: DuplicateError}.ParseToJsonObjectLazy(`{"a":{"k":1,"k":2}}`)
+	assert.NoError(t, err)
+	node := obj.data["a"].(*lazyNode)
+
+	_, first := node.load()
+	assert.ErrorIs(t, first, ErrDuplicateKey)
+	node.raw = []byte(`{"k":1}`)
+	_, second := node.load()
+	assert.Equal(t, first, second)
+	assert.Equal(t, json.RawMessage(`{"k":1}`), node.value())
+}
@@ -0,0 +1,132 @@
+package zjson
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrLimitExceeded = errors.New("limit exceeded")
+
+// Limits 限制输入的规模，用于解析不可信的输入，值为 0 表示不限制。
+// 设置了任一限制时使用内置解析器解析输入，超出限制立即停止解析并返回 *LimitError。
+type Limits struct {
+	// MaxInputBytes 限制输入的字节数，在解析前检查
+	MaxInputBytes int
+	// MaxDepth 限制对象和数组的嵌套层数，顶层容器为第 1 层
+	MaxDepth int
+	// MaxStringLength 限制解码后字符串的字节数，同时适用于键和值
+	MaxStringLength int
+	MaxObjectKeys   int
+	MaxArrayLength  int
+	// MaxNodes 限制值的总数，包括容器本身和所有嵌套的值
+	MaxNodes int
+}
+
+// LimitError 描述超出的限制，Limit 为 Limits 中对应的字段名
+type LimitError struct {
+	Limit string
+	Max   int
+	// Path 为超出限制的位置，格式与 PathError.Path 相同
+	Path string
+	// Offset 为解析时超出限制的字节偏移，写入时为 -1
+	Offset int
+}
+
+func (e *LimitError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%v: %s %d", ErrLimitExceeded, e.Limit, e.Max)
+	if e.Path != "" {
+		fmt.Fprintf(&sb, " at path '%s'", e.Path)
+	}
+	if e.Offset >= 0 {
+		fmt.Fprintf(&sb, " (offset %d)", e.Offset)
+	}
+	return sb.String()
+}
+
+func (e *LimitError) Unwrap() error {
+	return ErrLimitExceeded
+}
+
+func (l Limits) enabled() bool {
+	return l != Limits{}
+}
+
+func (c *Config) limits() Limits {
+	if c == nil {
+		return Limits{}
+	}
+	return c.Limits
+}
+
+func (c *Config) checkInput(data []byte) error {
+	if max := c.limits().MaxInputBytes; max > 0 && len(data) > max {
+		return &LimitError{Limit: "MaxInputBytes", Max: max, Offset: max}
+	}
+	return nil
+}
+
+func (d *decoder) limitError(limit string, max int) error {
+	return &LimitError{Limit: limit, Max: max, Path: d.currentPath(), Offset: d.pos}
+}
+
+// TryPut 与 Put 相同，但会先按容器配置的 Limits 检查新值，超出限制时不写入并返回 *LimitError。
+// MaxDepth 以当前容器为第 1 层计算，MaxNodes 只统计新值本身。
+func (jo *JsonObject) TryPut(key string, value any) error {
+	jo.mu.Lock()
+	defer jo.mu.Unlock()
+	limits := jo.cfg.limits()
+	if _, exists := jo.data[key]; !exists && limits.MaxObjectKeys > 0 && len(jo.data) >= limits.MaxObjectKeys {
+		return &LimitError{Limit: "MaxObjectKeys", Max: limits.MaxObjectKeys, Offset: -1}
+	}
+	if max := limits.MaxStringLength; max > 0 && len(key) > max {
+		return &LimitError{Limit: "MaxStringLength", Max: max, Path: key, Offset: -1}
+	}
+	if err := jo.cfg.checkValue(key, value); err != nil {
+		return err
+	}
+	jo.set(key, value)
+	return nil
+}
+
+// TryAdd 与 Add 相同，检查规则与 TryPut 一致
+func (ja *JsonArray) TryAdd(value any) error {
+	ja.mu.Lock()
+	defer ja.mu.Unlock()
+	limits := ja.cfg.limits()
+	if limits.MaxArrayLength > 0 && len(ja.data) >= limits.MaxArrayLength {
+		return &LimitError{Limit: "MaxArrayLength", Max: limits.MaxArrayLength, Offset: -1}
+	}
+	if err := ja.cfg.checkValue(strconv.Itoa(len(ja.data)), value); err != nil {
+		return err
+	}
+	ja.data = append(ja.data, value)
+	return nil
+}
+
+// checkValue 将值序列化后交给内置解析器检查，复用解析时的限制逻辑
+func (c *Config) checkValue(path string, value any) error {
+	limits := c.limits()
+	if !limits.enabled() {
+		return nil
+	}
+	raw, err := c.parser().AnyToJsonString(value)
+	if err != nil {
+		return fmt.Errorf("%w: cannot encode value: %w", ErrParse, err)
+	}
+
+	limits.MaxInputBytes = 0
+	d := newDecoder(raw, &Config{Limits: limits})
+	d.path = []string{path}
+	d.depth = 1
+	if _, err := d.decode(); err != nil {
+		var limitErr *LimitError
+		if errors.As(err, &limitErr) {
+			limitErr.Offset = -1
+		}
+		return err
+	}
+	return nil
+}
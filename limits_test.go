@@ -0,0 +1,92 @@
+package zjson
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func limitErrorOf(t *testing.T, err error) *LimitError {
+	t.Helper()
+	var limitErr *LimitError
+	assert.True(t, errors.As(err, &limitErr), "unexpected error: %v", err)
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+	return limitErr
+}
+
+// 测试解析时的各项限制
+func TestLimits_Parse(t *testing.T) {
+	parse := func(limits Limits, input string) error {
+		_, err := Config{Limits: limits}.ParseToJsonObject(input)
+		return err
+	}
+
+	limitErr := limitErrorOf(t, parse(Limits{MaxInputBytes: 8}, `{"name": "Tom"}`))
+	assert.Equal(t, "MaxInputBytes", limitErr.Limit)
+
+	limitErr = limitErrorOf(t, parse(Limits{MaxDepth: 2}, `{"a": {"b": {"c": 1}}}`))
+	assert.Equal(t, "MaxDepth", limitErr.Limit)
+	assert.Equal(t, "a.b", limitErr.Path)
+	assert.Equal(t, 12, limitErr.Offset)
+	assert.EqualError(t, limitErr, "limit exceeded: MaxDepth 2 at path 'a.b' (offset 12)")
+
+	limitErr = limitErrorOf(t, parse(Limits{MaxStringLength: 4}, `{"name": "Tommy"}`))
+	assert.Equal(t, "name", limitErr.Path)
+	limitErr = limitErrorOf(t, parse(Limits{MaxStringLength: 3}, `{"long_key": 1}`))
+	assert.Equal(t, "MaxStringLength", limitErr.Limit)
+
+	limitErr = limitErrorOf(t, parse(Limits{MaxObjectKeys: 2}, `{"a": 1, "b": 2, "c": 3}`))
+	assert.Equal(t, "MaxObjectKeys", limitErr.Limit)
+
+	limitErr = limitErrorOf(t, parse(Limits{MaxArrayLength: 2}, `{"items": [1, 2, 3]}`))
+	assert.Equal(t, "MaxArrayLength", limitErr.Limit)
+	assert.Equal(t, "items", limitErr.Path)
+
+	limitErr = limitErrorOf(t, parse(Limits{MaxNodes: 4}, `{"a": [1, 2, 3]}`))
+	assert.Equal(t, "MaxNodes", limitErr.Limit)
+	assert.Equal(t, "a.2", limitErr.Path)
+
+	obj, err := Config{Limits: Limits{MaxDepth: 3, MaxNodes: 5}}.ParseToJsonObject(`{"a": {"b": [1, 2]}}`)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"a":{"b":[1,2]}}`, obj.ToJsonStr())
+}
+
+// 测试深层嵌套的输入在达到限制时立即停止
+func TestLimits_DeepNesting(t *testing.T) {
+	input := strings.Repeat("[", 100000) + strings.Repeat("]", 100000)
+	_, err := Config{Limits: Limits{MaxDepth: 64}}.ParseToArray(input)
+	limitErr := limitErrorOf(t, err)
+	assert.Equal(t, 64, limitErr.Offset)
+}
+
+// 测试写入时检查限制
+func TestLimits_TryPutAndTryAdd(t *testing.T) {
+	cfg := Config{Limits: Limits{MaxObjectKeys: 2, MaxArrayLength: 2, MaxStringLength: 5, MaxDepth: 2}}
+	obj := cfg.NewJsonObject()
+	assert.Nil(t, obj.TryPut("a", 1))
+	assert.Nil(t, obj.TryPut("b", "short"))
+	assert.Nil(t, obj.TryPut("a", 2))
+	limitErr := limitErrorOf(t, obj.TryPut("c", 3))
+	assert.Equal(t, "MaxObjectKeys", limitErr.Limit)
+	assert.Equal(t, -1, limitErr.Offset)
+	assert.EqualError(t, limitErr, "limit exceeded: MaxObjectKeys 2")
+
+	limitErr = limitErrorOf(t, obj.TryPut("b", "too long"))
+	assert.Equal(t, "b", limitErr.Path)
+	limitErr = limitErrorOf(t, obj.TryPut("b", map[string]any{"x": []any{1}}))
+	assert.Equal(t, "MaxDepth", limitErr.Limit)
+	assert.Equal(t, "b.x", limitErr.Path)
+	assert.Equal(t, "short", obj.GetStringIgnoreError("b"))
+
+	arr := cfg.NewJsonArray()
+	assert.Nil(t, arr.TryAdd(map[string]any{"k": "v"}))
+	assert.Nil(t, arr.TryAdd(1))
+	limitErr = limitErrorOf(t, arr.TryAdd(2))
+	assert.Equal(t, "MaxArrayLength", limitErr.Limit)
+	assert.Equal(t, 2, arr.Length())
+
+	// 未设置限制时 TryPut 与 Put 相同
+	assert.Nil(t, NewJsonObject().TryPut("k", strings.Repeat("x", 100)))
+}
@@ -0,0 +1,292 @@
+package zjson
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ErrMergeConflict = errors.New("merge conflict")
+
+// ArrayStrategy 控制两侧都是数组时的合并方式
+type ArrayStrategy int
+
+const (
+	// ArrayReplace 使用新数组替换原数组
+	ArrayReplace ArrayStrategy = iota
+	// ArrayAppend 将新数组的元素追加到原数组末尾
+	ArrayAppend
+	// ArrayUnion 只追加原数组中不存在的元素，数字按数值比较
+	ArrayUnion
+	// ArrayMergeByIndex 相同下标的元素递归合并，多出的元素追加到末尾
+	ArrayMergeByIndex
+	// ArrayMergeByKey 按 MergeStrategy.ArrayKey 字段匹配对象元素并递归合并，未匹配的元素追加到末尾
+	ArrayMergeByKey
+)
+
+// ScalarStrategy 控制两侧的值不同且无法递归合并时的处理方式，包括类型不一致的情况
+type ScalarStrategy int
+
+const (
+	// ScalarOverwrite 使用新值
+	ScalarOverwrite ScalarStrategy = iota
+	// ScalarKeepExisting 保留原值
+	ScalarKeepExisting
+	// ScalarError 停止合并并返回错误，此时不会修改原对象
+	ScalarError
+)
+
+type MergeStrategy struct {
+	Arrays ArrayStrategy
+	// ArrayKey 为 ArrayMergeByKey 匹配元素使用的字段路径，如 "id"
+	ArrayKey string
+	Scalars  ScalarStrategy
+}
+
+// MergeOptions 的 MergeStrategy 为默认策略，Paths 按路径覆盖默认策略。
+// 路径格式与 PathError.Path 相同，可以使用 * 匹配任意一段，如 "services.*.ports"。
+type MergeOptions struct {
+	MergeStrategy
+	Paths map[string]MergeStrategy
+}
+
+// MergeConflict 记录一次值不同的合并，Kept 为 true 表示保留了原值
+type MergeConflict struct {
+	Path     string
+	Existing any
+	Incoming any
+	Kept     bool
+}
+
+// DeepMerge 将 other 递归合并到当前对象，返回所有发生冲突的位置。
+// 对象总是按键递归合并，other 中的值以深拷贝的形式写入，已有的子节点视图在合并后仍然有效。
+func (jo *JsonObject) DeepMerge(other *JsonObject, opts ...MergeOptions) ([]MergeConflict, error) {
+	if other == nil {
+		return nil, nil
+	}
+	m := &merger{opts: firstMergeOptions(opts)}
+	src := other.snapshot()
+	if m.opts.usesError() {
+		// 先在副本上合并，出错时保证原对象不被修改
+		dry := &merger{opts: m.opts}
+		if err := dry.mergeObject(jo.DeepClone(), src, ""); err != nil {
+			return dry.conflicts, err
+		}
+	}
+	err := m.mergeObject(jo, src, "")
+	return m.conflicts, err
+}
+
+func firstMergeOptions(opts []MergeOptions) MergeOptions {
+	if len(opts) > 0 {
+		return opts[0]
+	}
+	return MergeOptions{}
+}
+
+func (o MergeOptions) usesError() bool {
+	if o.Scalars == ScalarError {
+		return true
+	}
+	for _, strategy := range o.Paths {
+		if strategy.Scalars == ScalarError {
+			return true
+		}
+	}
+	return false
+}
+
+// strategyFor 优先使用完全匹配的路径，其次使用按字典序第一个匹配的通配路径
+func (o MergeOptions) strategyFor(path string) MergeStrategy {
+	if strategy, ok := o.Paths[path]; ok {
+		return strategy
+	}
+	patterns := make([]string, 0, len(o.Paths))
+	for pattern := range o.Paths {
+		if strings.Contains(pattern, "*") {
+			patterns = append(patterns, pattern)
+		}
+	}
+	sort.Strings(patterns)
+	for _, pattern := range patterns {
+		if matchPath(pattern, path) {
+			return o.Paths[pattern]
+		}
+	}
+	return o.MergeStrategy
+}
+
+func matchPath(pattern, path string) bool {
+	patternSegs, pathSegs := strings.Split(pattern, "."), strings.Split(path, ".")
+	if len(patternSegs) != len(pathSegs) {
+		return false
+	}
+	for i, seg := range patternSegs {
+		if seg != "*" && seg != pathSegs[i] {
+			return false
+		}
+	}
+	return true
+}
+
+type merger struct {
+	opts      MergeOptions
+	conflicts []MergeConflict
+}
+
+func (m *merger) mergeObject(dst *JsonObject, src map[string]any, path string) error {
+	dst.mu.Lock()
+	defer dst.mu.Unlock()
+	for _, key := range sortedKeys(src) {
+		incoming := src[key]
+		existing, exist := dst.value(key)
+		if !exist {
+			dst.set(key, cloneValue(normalizeValue(incoming)))
+			continue
+		}
+		merged, err := m.mergeValue(dst.cfg, dst.policy, joinPath(path, key), existing, incoming)
+		if err != nil {
+			return err
+		}
+		dst.set(key, merged)
+	}
+	return nil
+}
+
+// mergeValue 返回合并后应写回父节点的值，cfg 和 policy 用于包装原生子节点
+func (m *merger) mergeValue(cfg *Config, policy CoercionPolicy, path string, existing, incoming any) (any, error) {
+	existing, incoming = resolve(existing), normalizeValue(incoming)
+
+	if srcMap, ok := incoming.(map[string]any); ok {
+		switch dst := existing.(type) {
+		case *JsonObject:
+			return dst, m.mergeObject(dst, srcMap, path)
+		case map[string]any:
+			view := newObjectView(dst, cfg, policy)
+			return view, m.mergeObject(view, srcMap, path)
+		}
+	}
+	if srcSlice, ok := incoming.([]any); ok {
+		switch dst := existing.(type) {
+		case *JsonArray:
+			dst.mu.Lock()
+			defer dst.mu.Unlock()
+			merged, err := m.mergeArray(dst.cfg, dst.policy, path, dst.data, srcSlice)
+			if err != nil {
+				return nil, err
+			}
+			dst.data = merged
+			return dst, nil
+		case []any:
+			return m.mergeArray(cfg, policy, path, dst, srcSlice)
+		}
+	}
+	return m.mergeScalar(path, existing, incoming)
+}
+
+func (m *merger) mergeArray(cfg *Config, policy CoercionPolicy, path string, dst, src []any) ([]any, error) {
+	strategy := m.opts.strategyFor(path)
+	switch strategy.Arrays {
+	case ArrayAppend:
+		return append(dst, cloneSlice(src)...), nil
+	case ArrayUnion:
+		seen := make(map[string]struct{}, len(dst))
+		for _, val := range dst {
+			seen[canonicalKey(val)] = struct{}{}
+		}
+		for _, val := range src {
+			key := canonicalKey(val)
+			if _, dup := seen[key]; dup {
+				continue
+			}
+			seen[key] = struct{}{}
+			dst = append(dst, cloneValue(val))
+		}
+		return dst, nil
+	case ArrayMergeByIndex:
+		for i, val := range src {
+			if i >= len(dst) {
+				dst = append(dst, cloneValue(normalizeValue(val)))
+				continue
+			}
+			merged, err := m.mergeValue(cfg, policy, joinPath(path, strconv.Itoa(i)), dst[i], val)
+			if err != nil {
+				return nil, err
+			}
+			dst[i] = merged
+		}
+		return dst, nil
+	case ArrayMergeByKey:
+		return m.mergeByKey(cfg, policy, path, strategy.ArrayKey, dst, src)
+	default:
+		return cloneSlice(src), nil
+	}
+}
+
+// mergeByKey 只匹配字段存在的元素，同一个键在原数组中出现多次时与第一个匹配
+func (m *merger) mergeByKey(cfg *Config, policy CoercionPolicy, path, field string, dst, src []any) ([]any, error) {
+	index := make(map[string]int, len(dst))
+	for i, val := range dst {
+		if key, ok := lookupPath(val, field); ok {
+			canonical := canonicalKey(key)
+			if _, dup := index[canonical]; !dup {
+				index[canonical] = i
+			}
+		}
+	}
+	for _, val := range src {
+		key, ok := lookupPath(val, field)
+		if !ok {
+			dst = append(dst, cloneValue(normalizeValue(val)))
+			continue
+		}
+		i, matched := index[canonicalKey(key)]
+		if !matched {
+			index[canonicalKey(key)] = len(dst)
+			dst = append(dst, cloneValue(normalizeValue(val)))
+			continue
+		}
+		merged, err := m.mergeValue(cfg, policy, joinPath(path, strconv.Itoa(i)), dst[i], val)
+		if err != nil {
+			return nil, err
+		}
+		dst[i] = merged
+	}
+	return dst, nil
+}
+
+func (m *merger) mergeScalar(path string, existing, incoming any) (any, error) {
+	if deepEqual(existing, incoming, EqualOptions{NumericEquivalence: true}) {
+		return existing, nil
+	}
+	conflict := MergeConflict{Path: path, Existing: normalizeValue(existing), Incoming: incoming}
+	switch m.opts.strategyFor(path).Scalars {
+	case ScalarKeepExisting:
+		conflict.Kept = true
+		m.conflicts = append(m.conflicts, conflict)
+		return existing, nil
+	case ScalarError:
+		conflict.Kept = true
+		m.conflicts = append(m.conflicts, conflict)
+		return nil, &PathError{
+			Path:     path,
+			Expected: typeName(conflict.Existing),
+			Actual:   typeName(incoming),
+			Value:    incoming,
+			Err:      ErrMergeConflict,
+			msg:      fmt.Sprintf("%v: path '%s': existing %s, incoming %s", ErrMergeConflict, path, canonicalKey(conflict.Existing), canonicalKey(incoming)),
+		}
+	default:
+		m.conflicts = append(m.conflicts, conflict)
+		return cloneValue(incoming), nil
+	}
+}
+
+func joinPath(path, segment string) string {
+	if path == "" {
+		return segment
+	}
+	return path + "." + segment
+}
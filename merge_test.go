@@ -0,0 +1,86 @@
+package zjson
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 测试默认策略：对象递归合并，数组替换，标量覆盖
+func TestDeepMerge_Default(t *testing.T) {
+	base, _ := ParseToJsonObject(`{"server": {"host": "localhost", "port": 8080}, "tags": ["a"], "debug": false}`)
+	server := base.GetJsonObjectIgnoreError("server")
+	override, _ := ParseToJsonObject(`{"server": {"port": 9090, "tls": true}, "tags": ["b"], "debug": false, "name": "svc"}`)
+
+	conflicts, err := base.DeepMerge(override)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"debug":false,"name":"svc","server":{"host":"localhost","port":9090,"tls":true},"tags":["b"]}`, base.ToJsonStr())
+	assert.Equal(t, []MergeConflict{{Path: "server.port", Existing: 8080.0, Incoming: 9090.0}}, conflicts)
+	// 合并前获取的视图仍然有效
+	assert.Equal(t, 9090, server.GetIntIgnoreError("port"))
+
+	// 写入的是深拷贝
+	override.GetJsonObjectIgnoreError("server").Put("tls", false)
+	assert.True(t, base.GetJsonObjectIgnoreError("server").GetBoolIgnoreError("tls"))
+}
+
+// 测试数组合并策略
+func TestDeepMerge_Arrays(t *testing.T) {
+	merge := func(strategy MergeStrategy, dst, src string) string {
+		base, _ := ParseToJsonObject(`{"list": ` + dst + `}`)
+		other, _ := ParseToJsonObject(`{"list": ` + src + `}`)
+		_, err := base.DeepMerge(other, MergeOptions{MergeStrategy: strategy})
+		assert.Nil(t, err)
+		return base.GetJsonArrayIgnoreError("list").ToJsonStr()
+	}
+
+	assert.Equal(t, `[1,2,2,3]`, merge(MergeStrategy{Arrays: ArrayAppend}, `[1, 2]`, `[2, 3]`))
+	assert.Equal(t, `[1,2,3]`, merge(MergeStrategy{Arrays: ArrayUnion}, `[1, 2]`, `[2.0, 3]`))
+	assert.Equal(t, `[{"a":1,"b":2},5]`, merge(MergeStrategy{Arrays: ArrayMergeByIndex}, `[{"a": 1}]`, `[{"b": 2}, 5]`))
+	assert.Equal(t,
+		`[{"id":1,"name":"x","port":80},{"id":2,"name":"y"},{"id":3,"name":"z"},{"name":"anonymous"}]`,
+		merge(MergeStrategy{Arrays: ArrayMergeByKey, ArrayKey: "id"},
+			`[{"id": 1, "name": "x"}, {"id": 2, "name": "y"}]`,
+			`[{"id": 1, "port": 80}, {"id": 3, "name": "z"}, {"name": "anonymous"}]`))
+}
+
+// 测试按路径指定策略，支持通配符
+func TestDeepMerge_PathStrategies(t *testing.T) {
+	base, _ := ParseToJsonObject(`{"services": {"api": {"ports": [80], "replicas": 1}, "web": {"ports": [443], "replicas": 2}}, "version": 1}`)
+	other, _ := ParseToJsonObject(`{"services": {"api": {"ports": [8080], "replicas": 3}, "web": {"ports": [443, 8443]}}, "version": 2}`)
+
+	conflicts, err := base.DeepMerge(other, MergeOptions{
+		Paths: map[string]MergeStrategy{
+			"services.*.ports": {Arrays: ArrayUnion},
+			"version":          {Scalars: ScalarKeepExisting},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"services":{"api":{"ports":[80,8080],"replicas":3},"web":{"ports":[443,8443],"replicas":2}},"version":1}`, base.ToJsonStr())
+	assert.Equal(t, []MergeConflict{
+		{Path: "services.api.replicas", Existing: 1.0, Incoming: 3.0},
+		{Path: "version", Existing: 1.0, Incoming: 2.0, Kept: true},
+	}, conflicts)
+}
+
+// 测试冲突时报错，原对象保持不变
+func TestDeepMerge_ErrorOnConflict(t *testing.T) {
+	base, _ := ParseToJsonObject(`{"a": 1, "b": {"c": "x"}, "d": 1}`)
+	other, _ := ParseToJsonObject(`{"a": 1.0, "b": {"c": {"nested": true}}, "e": 2}`)
+
+	conflicts, err := base.DeepMerge(other, MergeOptions{MergeStrategy: MergeStrategy{Scalars: ScalarError}})
+	assert.ErrorIs(t, err, ErrMergeConflict)
+	var pathErr *PathError
+	assert.True(t, errors.As(err, &pathErr))
+	assert.Equal(t, "b.c", pathErr.Path)
+	assert.Equal(t, "string", pathErr.Expected)
+	assert.Equal(t, "object", pathErr.Actual)
+	assert.EqualError(t, err, `merge conflict: path 'b.c': existing "x", incoming {"nested":true}`)
+	assert.Len(t, conflicts, 1)
+	assert.Equal(t, `{"a":1,"b":{"c":"x"},"d":1}`, base.ToJsonStr())
+
+	conflicts, err = base.DeepMerge(nil)
+	assert.Nil(t, err)
+	assert.Empty(t, conflicts)
+}
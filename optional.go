@@ -0,0 +1,132 @@
+package zjson
+
+import "fmt"
+
+type optionalState int
+
+const (
+	optionalMissing optionalState = iota
+	optionalNull
+	optionalPresent
+)
+
+// Optional 区分键不存在、值为 null 和有值三种状态，适用于 PATCH 这类需要区分“未提供”和“置空”的场景
+type Optional[T any] struct {
+	value T
+	state optionalState
+}
+
+func Present[T any](value T) Optional[T] {
+	return Optional[T]{value: value, state: optionalPresent}
+}
+
+func Null[T any]() Optional[T] {
+	return Optional[T]{state: optionalNull}
+}
+
+func Missing[T any]() Optional[T] {
+	return Optional[T]{}
+}
+
+func (o Optional[T]) IsPresent() bool {
+	return o.state == optionalPresent
+}
+
+func (o Optional[T]) IsNull() bool {
+	return o.state == optionalNull
+}
+
+func (o Optional[T]) IsMissing() bool {
+	return o.state == optionalMissing
+}
+
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.state == optionalPresent
+}
+
+// OrElse 在没有值（缺失或为 null）时返回 def
+func (o Optional[T]) OrElse(def T) T {
+	if o.state == optionalPresent {
+		return o.value
+	}
+	return def
+}
+
+func (o Optional[T]) String() string {
+	switch o.state {
+	case optionalNull:
+		return "null"
+	case optionalPresent:
+		return fmt.Sprint(o.value)
+	}
+	return "missing"
+}
+
+// Lookup 返回键对应的值以及键是否存在，可以区分值为 null 和键不存在；
+// 返回的对象和数组与 Get 一样可能与容器共享数据
+func (jo *JsonObject) Lookup(key string) (any, bool) {
+	jo.mu.RLock()
+	defer jo.mu.RUnlock()
+	val, exist := jo.data[key]
+	return plainValue(val), exist
+}
+
+// IsNull 只有键存在且值为 null 时返回 true
+func (jo *JsonObject) IsNull(key string) bool {
+	val, exist := jo.Lookup(key)
+	return exist && val == nil
+}
+
+// Lookup 返回的对象和数组与 Get 一样可能与容器共享数据
+func (ja *JsonArray) Lookup(index int) (any, bool) {
+	ja.mu.RLock()
+	defer ja.mu.RUnlock()
+	if index < 0 || index >= len(ja.data) {
+		return nil, false
+	}
+	return plainValue(ja.data[index]), true
+}
+
+func (ja *JsonArray) IsNull(index int) bool {
+	val, exist := ja.Lookup(index)
+	return exist && val == nil
+}
+
+// GetOptional 读取键并转换为 T，转换规则与 GetInt 等方法一致，只有值存在但无法转换时返回错误
+func GetOptional[T any](jo *JsonObject, key string) (Optional[T], error) {
+	jo.mu.RLock()
+	defer jo.mu.RUnlock()
+
+	val, exist := jo.value(key)
+	if !exist {
+		return Missing[T](), nil
+	} else if val == nil {
+		return Null[T](), nil
+	}
+	convert, kind := coercerFor[T]()
+	res, err := convert(val, jo.coercionPolicy())
+	if err != nil {
+		return Missing[T](), keyTypeMismatch(key, kind, val, err)
+	}
+	return Present(res), nil
+}
+
+// GetOptionalAt 读取数组元素，越界视为缺失
+func GetOptionalAt[T any](ja *JsonArray, index int) (Optional[T], error) {
+	ja.mu.RLock()
+	defer ja.mu.RUnlock()
+
+	if index < 0 || index >= len(ja.data) {
+		return Missing[T](), nil
+	}
+	val := resolve(ja.data[index])
+	if val == nil {
+		return Null[T](), nil
+	}
+	convert, kind := coercerFor[T]()
+	res, err := convert(val, ja.coercionPolicy())
+	if err != nil {
+		return Missing[T](), indexTypeMismatch(index, kind, val, err)
+	}
+	return Present(res), nil
+}
@@ -0,0 +1,73 @@
+package zjson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJsonObject_LookupAndIsNull(t *testing.T) {
+	obj, err := ParseToJsonObject(`{"name":null,"age":0}`)
+	assert.NoError(t, err)
+
+	val, exist := obj.Lookup("name")
+	assert.True(t, exist)
+	assert.Nil(t, val)
+	_, exist = obj.Lookup("email")
+	assert.False(t, exist)
+
+	assert.True(t, obj.IsNull("name"))
+	assert.False(t, obj.IsNull("age"))
+	assert.False(t, obj.IsNull("email"))
+}
+
+func TestGetOptional(t *testing.T) {
+	patch, err := ParseToJsonObject(`{"name":null,"age":"31","tags":["a"],"bad":"x"}`)
+	assert.NoError(t, err)
+
+	name, err := GetOptional[string](patch, "name")
+	assert.NoError(t, err)
+	assert.True(t, name.IsNull())
+	assert.Equal(t, "anonymous", name.OrElse("anonymous"))
+
+	age, err := GetOptional[int](patch, "age")
+	assert.NoError(t, err)
+	assert.True(t, age.IsPresent())
+	v, ok := age.Get()
+	assert.True(t, ok)
+	assert.Equal(t, 31, v)
+
+	email, err := GetOptional[string](patch, "email")
+	assert.NoError(t, err)
+	assert.True(t, email.IsMissing())
+	assert.Equal(t, "missing", email.String())
+
+	tags, err := GetOptional[*JsonArray](patch, "tags")
+	assert.NoError(t, err)
+	assert.Equal(t, "a", tags.OrElse(nil).Get(0))
+
+	_, err = GetOptional[int](patch, "bad")
+	assert.Error(t, err)
+}
+
+func TestJsonArray_Optional(t *testing.T) {
+	arr, err := ParseToArray(`[1,null]`)
+	assert.NoError(t, err)
+
+	assert.True(t, arr.IsNull(1))
+	assert.False(t, arr.IsNull(2))
+	_, exist := arr.Lookup(2)
+	assert.False(t, exist)
+
+	first, err := GetOptionalAt[float64](arr, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, first.OrElse(0))
+
+	second, err := GetOptionalAt[float64](arr, 1)
+	assert.NoError(t, err)
+	assert.True(t, second.IsNull())
+
+	third, err := GetOptionalAt[float64](arr, 2)
+	assert.NoError(t, err)
+	assert.True(t, third.IsMissing())
+}
@@ -0,0 +1,50 @@
+package zjson
+
+import (
+	"strconv"
+	"strings"
+)
+
+// lookupPath 按点号分隔的路径读取嵌套值，数组使用数字下标，如 "items.0.price"
+func lookupPath(val any, path string) (any, bool) {
+	if path == "" {
+		return resolve(val), true
+	}
+	for _, segment := range strings.Split(path, ".") {
+		var ok bool
+		if val, ok = lookupSegment(val, segment); !ok {
+			return nil, false
+		}
+	}
+	return val, true
+}
+
+func lookupSegment(val any, segment string) (any, bool) {
+	switch v := resolve(val).(type) {
+	case *JsonObject:
+		v.mu.RLock()
+		defer v.mu.RUnlock()
+		return v.value(segment)
+	case map[string]any:
+		item, exist := v[segment]
+		return resolve(item), exist
+	case *JsonArray:
+		index, err := strconv.Atoi(segment)
+		if err != nil {
+			return nil, false
+		}
+		v.mu.RLock()
+		defer v.mu.RUnlock()
+		if index < 0 || index >= len(v.data) {
+			return nil, false
+		}
+		return resolve(v.data[index]), true
+	case []any:
+		index, err := strconv.Atoi(segment)
+		if err != nil || index < 0 || index >= len(v) {
+			return nil, false
+		}
+		return resolve(v[index]), true
+	}
+	return nil, false
+}
@@ -0,0 +1,86 @@
+package zjson
+
+import "sync/atomic"
+
+// CoercionPolicy 控制类型化读取方法在值类型与目标类型不一致时的转换规则
+type CoercionPolicy int
+
+const (
+	// CoercionDefault 保持原有规则：数字字符串可转换，浮点数截断取整，超出范围时回绕
+	CoercionDefault CoercionPolicy = iota + 1
+	// CoercionStrict 只接受与目标类型一致的 JSON 类型，整数不允许截断或溢出
+	CoercionStrict
+	// CoercionNumericSafe 在默认规则基础上拒绝截断小数和数值溢出
+	CoercionNumericSafe
+	// CoercionLenient 在默认规则基础上额外接受 "yes"/"no"/"1"/"0" 布尔值和 "1,000" 形式的数字
+	CoercionLenient
+)
+
+var coercionPolicy atomic.Int32
+
+func init() {
+	coercionPolicy.Store(int32(CoercionDefault))
+}
+
+// SetCoercionPolicy 设置全局默认策略，未单独设置策略的容器使用该策略
+func SetCoercionPolicy(policy CoercionPolicy) {
+	coercionPolicy.Store(int32(policy))
+}
+
+func GetCoercionPolicy() CoercionPolicy {
+	return CoercionPolicy(coercionPolicy.Load())
+}
+
+func (p CoercionPolicy) String() string {
+	switch p {
+	case CoercionDefault:
+		return "default"
+	case CoercionStrict:
+		return "strict"
+	case CoercionNumericSafe:
+		return "numeric-safe"
+	case CoercionLenient:
+		return "lenient"
+	}
+	return "unknown"
+}
+
+func (jo *JsonObject) SetCoercionPolicy(policy CoercionPolicy) {
+	jo.mu.Lock()
+	defer jo.mu.Unlock()
+	jo.policy = policy
+}
+
+// CoercionPolicy 返回容器当前生效的策略
+func (jo *JsonObject) CoercionPolicy() CoercionPolicy {
+	jo.mu.RLock()
+	defer jo.mu.RUnlock()
+	return jo.coercionPolicy()
+}
+
+// coercionPolicy 调用方需持有锁
+func (jo *JsonObject) coercionPolicy() CoercionPolicy {
+	if jo.policy != 0 {
+		return jo.policy
+	}
+	return jo.cfg.coercionPolicy()
+}
+
+func (ja *JsonArray) SetCoercionPolicy(policy CoercionPolicy) {
+	ja.mu.Lock()
+	defer ja.mu.Unlock()
+	ja.policy = policy
+}
+
+func (ja *JsonArray) CoercionPolicy() CoercionPolicy {
+	ja.mu.RLock()
+	defer ja.mu.RUnlock()
+	return ja.coercionPolicy()
+}
+
+func (ja *JsonArray) coercionPolicy() CoercionPolicy {
+	if ja.policy != 0 {
+		return ja.policy
+	}
+	return ja.cfg.coercionPolicy()
+}
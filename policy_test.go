@@ -0,0 +1,117 @@
+package zjson
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoercionPolicy_Default(t *testing.T) {
+	obj, err := ParseToJsonObject(`{"frac":12.9,"str":"12","big":1e20,"yes":"yes"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, CoercionDefault, obj.CoercionPolicy())
+
+	assert.Equal(t, 12, obj.GetIntIgnoreError("frac"))
+	assert.Equal(t, 12, obj.GetIntIgnoreError("str"))
+	_, err = obj.GetBool("yes")
+	assert.Error(t, err)
+}
+
+func TestCoercionPolicy_Strict(t *testing.T) {
+	obj, err := ParseToJsonObject(`{"int":12,"frac":12.9,"str":"12","bool":"true","arr":[1]}`)
+	assert.NoError(t, err)
+	obj.SetCoercionPolicy(CoercionStrict)
+
+	assert.Equal(t, 12, obj.GetIntIgnoreError("int"))
+	_, err = obj.GetInt("frac")
+	assert.ErrorContains(t, err, "strict policy does not truncate fractional number 12.9")
+	_, err = obj.GetInt("str")
+	assert.ErrorContains(t, err, "strict policy does not convert strings to numbers")
+	_, err = obj.GetBool("bool")
+	assert.ErrorContains(t, err, "strict policy does not convert strings to booleans")
+	_, err = obj.GetString("int")
+	assert.ErrorContains(t, err, "strict policy does not convert number to string")
+	assert.True(t, errors.Is(err, ErrTypeMismatch))
+}
+
+func TestCoercionPolicy_NumericSafe(t *testing.T) {
+	arr, err := ParseToArray(`[12.0,12.9,"12.5",1e20,"7",[1]]`)
+	assert.NoError(t, err)
+	arr.SetCoercionPolicy(CoercionNumericSafe)
+
+	assert.Equal(t, 12, arr.GetIntIgnoreError(0))
+	_, err = arr.GetInt(1)
+	assert.ErrorContains(t, err, "numeric-safe policy does not truncate fractional number 12.9")
+	_, err = arr.GetInt(2)
+	assert.Error(t, err)
+	_, err = arr.GetInt64(3)
+	assert.ErrorContains(t, err, "overflow")
+	assert.Equal(t, 7, arr.GetIntIgnoreError(4))
+	_, err = arr.GetString(5)
+	assert.ErrorContains(t, err, "does not convert array to string")
+
+	_, err = arr.ToIntSlice()
+	assert.ErrorContains(t, err, "value at index 1 is not an integer")
+}
+
+func TestCoercionPolicy_Lenient(t *testing.T) {
+	obj, err := ParseToJsonObject(`{"yes":"Yes","zero":"0","one":1,"thousands":"1,000","spaced":" 42 "}`)
+	assert.NoError(t, err)
+	obj.SetCoercionPolicy(CoercionLenient)
+
+	assert.True(t, obj.GetBoolIgnoreError("yes"))
+	assert.False(t, obj.GetBoolIgnoreError("zero"))
+	assert.True(t, obj.GetBoolIgnoreError("one"))
+	assert.Equal(t, 1000, obj.GetIntIgnoreError("thousands"))
+	assert.Equal(t, 1000.0, obj.GetFloatIgnoreError("thousands"))
+	assert.Equal(t, 42, obj.GetIntIgnoreError("spaced"))
+}
+
+// 测试宽松策略只接受合法的千位分组
+func TestCoercionPolicy_LenientThousands(t *testing.T) {
+	obj, err := ParseToJsonObject(`{"big":"-1,234,567.5","exp":"1,000e3","pair":"1,5","double":"1,,2","short":"12,34","lead":",100","long":"1234,567"}`)
+	assert.NoError(t, err)
+	obj.SetCoercionPolicy(CoercionLenient)
+
+	assert.Equal(t, -1234567.5, obj.GetFloatIgnoreError("big"))
+	assert.Equal(t, int64(1000000), obj.GetInt64IgnoreError("exp"))
+	for _, key := range []string{"pair", "double", "short", "lead", "long"} {
+		_, err := obj.GetInt(key)
+		assert.ErrorIs(t, err, ErrTypeMismatch, key)
+		assert.ErrorContains(t, err, "invalid thousands separators", key)
+		_, err = obj.GetFloat(key)
+		assert.ErrorIs(t, err, ErrTypeMismatch, key)
+	}
+}
+
+// 测试深拷贝和子视图保留容器上设置的策略
+func TestCoercionPolicy_CloneAndViews(t *testing.T) {
+	obj, err := ParseToJsonObject(`{"n":"12","child":{"n":"12"},"list":[{"n":"12"}]}`)
+	assert.NoError(t, err)
+	obj.SetCoercionPolicy(CoercionStrict)
+
+	_, err = obj.DeepClone().GetInt("n")
+	assert.ErrorIs(t, err, ErrTypeMismatch)
+	_, err = obj.GetJsonObjectIgnoreError("child").GetInt("n")
+	assert.ErrorIs(t, err, ErrTypeMismatch)
+	list := obj.GetJsonArrayIgnoreError("list")
+	assert.Equal(t, CoercionStrict, list.CoercionPolicy())
+	assert.Equal(t, CoercionStrict, list.DeepClone().CoercionPolicy())
+	assert.Equal(t, CoercionStrict, list.GetJsonObjectIgnoreError(0).CoercionPolicy())
+}
+
+func TestCoercionPolicy_Global(t *testing.T) {
+	defer SetCoercionPolicy(CoercionDefault)
+	SetCoercionPolicy(CoercionStrict)
+
+	obj := NewJsonObject()
+	obj.Put("str", "12")
+	_, err := obj.GetInt("str")
+	assert.Error(t, err)
+
+	// 容器上的设置优先于全局策略
+	obj.SetCoercionPolicy(CoercionDefault)
+	assert.Equal(t, 12, obj.GetIntIgnoreError("str"))
+	assert.Equal(t, CoercionStrict, GetCoercionPolicy())
+}
@@ -0,0 +1,98 @@
+package zjson
+
+// KeyFunc 从元素中提取用于比较的键，返回值按深度相等（数值等价）比较
+type KeyFunc func(value any) any
+
+// KeyByPath 使用元素内字段路径的值作为比较键
+func KeyByPath(path string) KeyFunc {
+	return func(value any) any {
+		fieldVal, _ := lookupPath(value, path)
+		return fieldVal
+	}
+}
+
+// 集合运算的结果保留元素首次出现的顺序，继承 ja 的配置和策略，对象和数组元素与源数组共享同一个视图；
+// key 为空时按元素本身比较，KeyFunc 收到的值与 Get 一致；other 为 nil 时视为空数组
+
+func (ja *JsonArray) Dedupe(key ...KeyFunc) *JsonArray {
+	return newSetBuilder(key).add(setValues(ja), nil).result(ja)
+}
+
+func (ja *JsonArray) Union(other *JsonArray, key ...KeyFunc) *JsonArray {
+	builder := newSetBuilder(key)
+	return builder.add(setValues(ja), nil).add(setValues(other), nil).result(ja)
+}
+
+func (ja *JsonArray) Intersect(other *JsonArray, key ...KeyFunc) *JsonArray {
+	builder := newSetBuilder(key)
+	otherKeys := builder.keySet(setValues(other))
+	return builder.add(setValues(ja), func(k string) bool { return otherKeys[k] }).result(ja)
+}
+
+func (ja *JsonArray) Difference(other *JsonArray, key ...KeyFunc) *JsonArray {
+	builder := newSetBuilder(key)
+	otherKeys := builder.keySet(setValues(other))
+	return builder.add(setValues(ja), func(k string) bool { return !otherKeys[k] }).result(ja)
+}
+
+func (ja *JsonArray) SymmetricDifference(other *JsonArray, key ...KeyFunc) *JsonArray {
+	builder := newSetBuilder(key)
+	left, right := setValues(ja), setValues(other)
+	leftKeys, rightKeys := builder.keySet(left), builder.keySet(right)
+	builder.add(left, func(k string) bool { return !rightKeys[k] })
+	builder.add(right, func(k string) bool { return !leftKeys[k] })
+	return builder.result(ja)
+}
+
+// setValues 返回参与集合运算的快照，nil 数组视为空数组
+func setValues(ja *JsonArray) []any {
+	if ja == nil {
+		return nil
+	}
+	return ja.shared()
+}
+
+type setBuilder struct {
+	key  KeyFunc
+	seen map[string]bool
+	data []any
+}
+
+func newSetBuilder(key []KeyFunc) *setBuilder {
+	builder := &setBuilder{seen: make(map[string]bool)}
+	if len(key) > 0 {
+		builder.key = key[0]
+	}
+	return builder
+}
+
+func (b *setBuilder) keyOf(value any) string {
+	if b.key != nil {
+		return canonicalKey(b.key(plainValue(value)))
+	}
+	return canonicalKey(value)
+}
+
+func (b *setBuilder) keySet(values []any) map[string]bool {
+	keys := make(map[string]bool, len(values))
+	for _, value := range values {
+		keys[b.keyOf(value)] = true
+	}
+	return keys
+}
+
+func (b *setBuilder) add(values []any, accept func(key string) bool) *setBuilder {
+	for _, value := range values {
+		k := b.keyOf(value)
+		if b.seen[k] || (accept != nil && !accept(k)) {
+			continue
+		}
+		b.seen[k] = true
+		b.data = append(b.data, value)
+	}
+	return b
+}
+
+func (b *setBuilder) result(ja *JsonArray) *JsonArray {
+	return ja.derive(b.data)
+}
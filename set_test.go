@@ -0,0 +1,53 @@
+package zjson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJsonArray_SetOperations(t *testing.T) {
+	a, err := ParseToArray(`["go","rust",{"id":1,"tags":["x"]},"go",3]`)
+	assert.NoError(t, err)
+	b := NewJsonArray()
+	b.AddAll("java", map[string]any{"tags": []any{"x"}, "id": 1}, 3, "go")
+
+	assert.JSONEq(t, `["go","rust",{"id":1,"tags":["x"]},3]`, a.Dedupe().ToJsonStr())
+	assert.JSONEq(t, `["go","rust",{"id":1,"tags":["x"]},3,"java"]`, a.Union(b).ToJsonStr())
+	assert.JSONEq(t, `["go",{"id":1,"tags":["x"]},3]`, a.Intersect(b).ToJsonStr())
+	assert.JSONEq(t, `["rust"]`, a.Difference(b).ToJsonStr())
+	assert.JSONEq(t, `["rust","java"]`, a.SymmetricDifference(b).ToJsonStr())
+}
+
+func TestJsonArray_SetOperationsWithKey(t *testing.T) {
+	a, err := ParseToArray(`[{"id":1,"v":"a"},{"id":2,"v":"b"},{"id":1,"v":"c"}]`)
+	assert.NoError(t, err)
+	b, err := ParseToArray(`[{"id":2,"v":"x"},{"id":3,"v":"y"}]`)
+	assert.NoError(t, err)
+
+	byID := KeyByPath("id")
+	assert.JSONEq(t, `[{"id":1,"v":"a"},{"id":2,"v":"b"}]`, a.Dedupe(byID).ToJsonStr())
+	assert.JSONEq(t, `[{"id":2,"v":"b"}]`, a.Intersect(b, byID).ToJsonStr())
+	assert.JSONEq(t, `[{"id":1,"v":"a"},{"id":3,"v":"y"}]`, a.SymmetricDifference(b, byID).ToJsonStr())
+	assert.Equal(t, 3, a.Union(b, byID).Length())
+	assert.Equal(t, 0, b.Difference(b).Length())
+}
+
+// 测试 KeyFunc 收到的值与 Get 一致，other 为 nil 时视为空数组
+func TestJsonArray_SetOperationsPlainValues(t *testing.T) {
+	arr, err := ParseToArray(`[{"id":1,"v":"a"},{"id":1,"v":"b"},{"id":2}]`)
+	assert.NoError(t, err)
+	arr.SetCoercionPolicy(CoercionStrict)
+	for range arr.Objects() {
+	}
+
+	deduped := arr.Dedupe(func(v any) any { return v.(map[string]any)["id"] })
+	assert.JSONEq(t, `[{"id":1,"v":"a"},{"id":2}]`, deduped.ToJsonStr())
+	assert.Equal(t, CoercionStrict, deduped.CoercionPolicy())
+	assert.Same(t, arr.GetJsonObjectIgnoreError(0), deduped.GetJsonObjectIgnoreError(0))
+
+	assert.Equal(t, 3, arr.Union(nil).Length())
+	assert.Equal(t, 0, arr.Intersect(nil).Length())
+	assert.Equal(t, 3, arr.Difference(nil).Length())
+	assert.Equal(t, 3, arr.SymmetricDifference(nil).Length())
+}
@@ -0,0 +1,203 @@
+package zjson
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type SortKey struct {
+	// Path 为元素内的字段路径，为空时比较元素本身
+	Path string
+	Desc bool
+	// NumericStrings 为 true 时可解析为数字的字符串按数值参与比较
+	NumericStrings bool
+}
+
+// 不同类型之间的全序：null < bool < number < string < array < object
+const (
+	rankNull = iota
+	rankBool
+	rankNumber
+	rankString
+	rankArray
+	rankObject
+	rankOther
+)
+
+// 排序在快照上进行且不持有锁，less 可以访问该数组；写回时只重排快照中仍在数组里的元素，
+// 排序期间并发加入或替换的元素保持原位置
+
+// Sort 使用 less 对数组进行稳定排序，less 收到的值与 Get 一致
+func (ja *JsonArray) Sort(less func(a, b any) bool) {
+	snapshot := ja.snapshot()
+	plains := make([]any, len(snapshot))
+	for i, v := range snapshot {
+		plains[i] = plainValue(v)
+	}
+	ja.sortSnapshot(snapshot, func(i, j int) bool {
+		return less(plains[i], plains[j])
+	})
+}
+
+// SortBy 按多个字段依次进行稳定排序，缺失字段视为 null
+func (ja *JsonArray) SortBy(keys ...SortKey) {
+	if len(keys) == 0 {
+		keys = []SortKey{{}}
+	}
+
+	snapshot := ja.snapshot()
+	ja.sortSnapshot(snapshot, func(i, j int) bool {
+		for _, key := range keys {
+			a, _ := lookupPath(snapshot[i], key.Path)
+			b, _ := lookupPath(snapshot[j], key.Path)
+			cmp := compareValues(a, b, key.NumericStrings)
+			if cmp == 0 {
+				continue
+			}
+			if key.Desc {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	})
+}
+
+// sortSnapshot 按 less 对快照下标排序后写回，less 的参数为快照下标
+func (ja *JsonArray) sortSnapshot(snapshot []any, less func(i, j int) bool) {
+	order := make([]int, len(snapshot))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return less(order[i], order[j])
+	})
+
+	ja.mu.Lock()
+	defer ja.mu.Unlock()
+	match := ja.matchSnapshot(snapshot)
+	// pos 记录快照元素当前所在的位置，按排序结果依次填入这些位置
+	pos := make([]int, len(snapshot))
+	for i := range pos {
+		pos[i] = -1
+	}
+	var slots []int
+	for i, j := range match {
+		if j >= 0 {
+			pos[j] = i
+			slots = append(slots, i)
+		}
+	}
+	values := make([]any, 0, len(slots))
+	for _, j := range order {
+		if pos[j] >= 0 {
+			values = append(values, resolve(ja.data[pos[j]]))
+		}
+	}
+	for k, slot := range slots {
+		ja.data[slot] = values[k]
+	}
+}
+
+// CompareValues 按 null < bool < number < string < array < object 的全序比较两个值
+func CompareValues(a, b any) int {
+	return compareValues(a, b, false)
+}
+
+func compareValues(a, b any, numericStrings bool) int {
+	a, b = normalizeValue(a), normalizeValue(b)
+	rankA, rankB := valueRank(a, numericStrings), valueRank(b, numericStrings)
+	if rankA != rankB {
+		return rankA - rankB
+	}
+
+	switch rankA {
+	case rankNull:
+		return 0
+	case rankBool:
+		boolA, boolB := a.(bool), b.(bool)
+		if boolA == boolB {
+			return 0
+		} else if !boolA {
+			return -1
+		}
+		return 1
+	case rankNumber:
+		return compareNumbers(sortNumber(a), sortNumber(b))
+	case rankString:
+		return strings.Compare(a.(string), b.(string))
+	case rankArray:
+		arrA, arrB := a.([]any), b.([]any)
+		for i := 0; i < len(arrA) && i < len(arrB); i++ {
+			if cmp := compareValues(arrA[i], arrB[i], numericStrings); cmp != 0 {
+				return cmp
+			}
+		}
+		return len(arrA) - len(arrB)
+	case rankObject:
+		objA, objB := a.(map[string]any), b.(map[string]any)
+		keysA, keysB := sortedKeys(objA), sortedKeys(objB)
+		for i := 0; i < len(keysA) && i < len(keysB); i++ {
+			if cmp := strings.Compare(keysA[i], keysB[i]); cmp != 0 {
+				return cmp
+			}
+			if cmp := compareValues(objA[keysA[i]], objB[keysB[i]], numericStrings); cmp != 0 {
+				return cmp
+			}
+		}
+		return len(keysA) - len(keysB)
+	}
+	return strings.Compare(canonicalKey(a), canonicalKey(b))
+}
+
+func valueRank(val any, numericStrings bool) int {
+	if _, ok := toNumber(val); ok {
+		return rankNumber
+	}
+	switch v := val.(type) {
+	case nil:
+		return rankNull
+	case bool:
+		return rankBool
+	case string:
+		if numericStrings {
+			if _, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				return rankNumber
+			}
+		}
+		return rankString
+	case []any:
+		return rankArray
+	case map[string]any:
+		return rankObject
+	}
+	return rankOther
+}
+
+func sortNumber(val any) number {
+	if n, ok := toNumber(val); ok {
+		return n
+	}
+	f, _ := strconv.ParseFloat(strings.TrimSpace(val.(string)), 64)
+	return number{f: f}
+}
+
+func compareNumbers(a, b number) int {
+	if a.isInt && b.isInt {
+		switch {
+		case a.i < b.i:
+			return -1
+		case a.i > b.i:
+			return 1
+		}
+		return 0
+	}
+	switch {
+	case a.f < b.f:
+		return -1
+	case a.f > b.f:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,103 @@
+package zjson
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJsonArray_SortBy(t *testing.T) {
+	arr, err := ParseToArray(`[
+		{"name":"pear","price":3},
+		{"name":"apple","price":1.5},
+		{"name":"fig"},
+		{"name":"banana","price":3}
+	]`)
+	assert.NoError(t, err)
+
+	arr.SortBy(SortKey{Path: "price"}, SortKey{Path: "name"})
+	names := arr.MapObjects(func(obj *JsonObject) any { return obj.Get("name") })
+	assert.JSONEq(t, `["fig","apple","banana","pear"]`, names.ToJsonStr())
+
+	arr.SortBy(SortKey{Path: "price", Desc: true})
+	names = arr.MapObjects(func(obj *JsonObject) any { return obj.Get("name") })
+	// 稳定排序，价格相同的元素保持原有顺序
+	assert.JSONEq(t, `["banana","pear","apple","fig"]`, names.ToJsonStr())
+}
+
+func TestJsonArray_SortMixedTypes(t *testing.T) {
+	arr, err := ParseToArray(`[{"a":1},"b",[1],2,true,null,"a",false,1.5]`)
+	assert.NoError(t, err)
+
+	arr.SortBy()
+	assert.JSONEq(t, `[null,false,true,1.5,2,"a","b",[1],{"a":1}]`, arr.ToJsonStr())
+}
+
+func TestJsonArray_SortNumericStrings(t *testing.T) {
+	arr := NewJsonArray()
+	arr.AddAll("10", "9", 8, "x")
+
+	arr.SortBy(SortKey{})
+	assert.JSONEq(t, `[8,"10","9","x"]`, arr.ToJsonStr())
+
+	arr.SortBy(SortKey{NumericStrings: true})
+	assert.JSONEq(t, `[8,"9","10","x"]`, arr.ToJsonStr())
+}
+
+func TestJsonArray_Sort(t *testing.T) {
+	arr := NewJsonArray()
+	arr.AddAll(3, 1, 2)
+
+	arr.Sort(func(a, b any) bool { return CompareValues(a, b) > 0 })
+	assert.JSONEq(t, `[3,2,1]`, arr.ToJsonStr())
+	assert.Equal(t, -1, CompareValues(nil, false))
+	assert.Equal(t, 0, CompareValues(1, 1.0))
+}
+
+// 测试 less 中可以访问当前数组和父对象，排序期间加入的元素保持原位置
+func TestJsonArray_SortReentrant(t *testing.T) {
+	parent, err := ParseToJsonObject(`{"list":[3,1,2]}`)
+	assert.NoError(t, err)
+	arr := parent.GetJsonArrayIgnoreError("list")
+
+	added := false
+	arr.Sort(func(a, b any) bool {
+		assert.GreaterOrEqual(t, arr.Length(), 3)
+		assert.NotEmpty(t, parent.ToJsonStr())
+		if !added {
+			added = true
+			arr.Insert(1, 10)
+		}
+		return CompareValues(a, b) < 0
+	})
+	assert.JSONEq(t, `[1,10,2,3]`, arr.ToJsonStr())
+
+	arr.SortBy(SortKey{Desc: true})
+	assert.JSONEq(t, `[10,3,2,1]`, arr.ToJsonStr())
+}
+
+// 测试排序与并发写入同时进行时不会丢失写入
+func TestJsonArray_SortConcurrentAdd(t *testing.T) {
+	arr := NewJsonArray()
+	for i := 0; i < 100; i++ {
+		arr.Add(i)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			arr.Sort(func(a, b any) bool { return CompareValues(a, b) > 0 })
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			arr.Add(100 + i)
+		}
+	}()
+	wg.Wait()
+	assert.Equal(t, 300, arr.Length())
+}
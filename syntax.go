@@ -0,0 +1,109 @@
+package zjson
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// excerptWidth 为错误摘录在出错位置两侧各保留的最大字符数
+const excerptWidth = 32
+
+// SyntaxError 描述输入中的语法错误，位置由内置解析器重新扫描输入得到，与安装的 JsonParser 无关
+type SyntaxError struct {
+	Msg string
+	// Offset 为出错位置的字节偏移，Line 和 Column 从 1 开始，Column 按字符计数
+	Offset int
+	Line   int
+	Column int
+	// Path 为出错前已经进入的位置，格式与 PathError.Path 相同，顶层为空
+	Path string
+	// Excerpt 为出错所在行的摘录，第二行用 ^ 指向出错位置
+	Excerpt string
+	// Err 为 JsonParser 返回的原始错误
+	Err error
+}
+
+func (e *SyntaxError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%v: %s at line %d, column %d (offset %d)", ErrParse, e.Msg, e.Line, e.Column, e.Offset)
+	if e.Path != "" {
+		fmt.Fprintf(&sb, ", path '%s'", e.Path)
+	}
+	if e.Excerpt != "" {
+		sb.WriteString("\n")
+		sb.WriteString(e.Excerpt)
+	}
+	return sb.String()
+}
+
+func (e *SyntaxError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{ErrParse}
+	}
+	return []error{ErrParse, e.Err}
+}
+
+func (d *decoder) syntaxError(format string, args ...any) *SyntaxError {
+	line, column, excerpt := locate(d.data, d.pos)
+	return &SyntaxError{
+		Msg:     fmt.Sprintf(format, args...),
+		Offset:  d.pos,
+		Line:    line,
+		Column:  column,
+		Path:    d.currentPath(),
+		Excerpt: excerpt,
+	}
+}
+
+// parseFailed 包装 JsonParser 返回的错误，输入存在语法错误时返回带位置信息的 SyntaxError
+func parseFailed(input []byte, err error) error {
+	if _, decodeErr := newDecoder(input, nil).decode(); decodeErr != nil {
+		if synErr, ok := decodeErr.(*SyntaxError); ok {
+			synErr.Err = err
+			return synErr
+		}
+	}
+	return fmt.Errorf("%w: %w", ErrParse, err)
+}
+
+func locate(data []byte, offset int) (line, column int, excerpt string) {
+	lineStart := bytes.LastIndexByte(data[:offset], '\n') + 1
+	lineEnd := bytes.IndexByte(data[offset:], '\n')
+	if lineEnd < 0 {
+		lineEnd = len(data)
+	} else {
+		lineEnd += offset
+	}
+	line = bytes.Count(data[:lineStart], []byte{'\n'}) + 1
+
+	before := []rune(strings.TrimRight(string(data[lineStart:offset]), "\r"))
+	after := []rune(strings.TrimRight(string(data[offset:lineEnd]), "\r"))
+	column = len(before) + 1
+
+	prefix, suffix := "", ""
+	if len(before) > excerptWidth {
+		before = before[len(before)-excerptWidth:]
+		prefix = "..."
+	}
+	if len(after) > excerptWidth {
+		after = after[:excerptWidth]
+		suffix = "..."
+	}
+	text := prefix + printable(before) + printable(after) + suffix
+	caret := strings.Repeat(" ", utf8.RuneCountInString(prefix)+len(before)) + "^"
+	return line, column, text + "\n" + caret
+}
+
+// printable 把制表符等控制字符替换为空格，保证 ^ 与摘录对齐
+func printable(runes []rune) string {
+	var sb strings.Builder
+	for _, r := range runes {
+		if r < 0x20 || r == 0x7f {
+			r = ' '
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
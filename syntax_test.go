@@ -0,0 +1,93 @@
+package zjson
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 测试语法错误的行列号、路径和摘录
+func TestSyntaxError_Location(t *testing.T) {
+	input := "{\n  \"name\": \"Tom\",\n  \"tags\": [\"a\", \"b\"}\n}"
+	_, err := ParseToJsonObject(input)
+
+	var synErr *SyntaxError
+	assert.True(t, errors.As(err, &synErr))
+	assert.True(t, errors.Is(err, ErrParse))
+	assert.Equal(t, 3, synErr.Line)
+	assert.Equal(t, 20, synErr.Column)
+	assert.Equal(t, 38, synErr.Offset)
+	assert.Equal(t, "tags", synErr.Path)
+	assert.Equal(t, "invalid character '}' after array element", synErr.Msg)
+	assert.Equal(t, "  \"tags\": [\"a\", \"b\"}\n                   ^", synErr.Excerpt)
+	assert.NotNil(t, synErr.Err)
+}
+
+// 测试数组和懒加载解析同样返回 SyntaxError
+func TestSyntaxError_ArrayAndLazy(t *testing.T) {
+	_, err := ParseToArray(`[1, {"a": tru}]`)
+	var synErr *SyntaxError
+	assert.True(t, errors.As(err, &synErr))
+	assert.Equal(t, "1.a", synErr.Path)
+	assert.Equal(t, 1, synErr.Line)
+	assert.Equal(t, 14, synErr.Column)
+
+	_, err = ParseToJsonObjectLazy(`{"a": {"b": [1, 2,]}}`)
+	assert.True(t, errors.As(err, &synErr))
+	assert.Equal(t, "a.b.2", synErr.Path)
+	assert.Equal(t, "invalid character ']' looking for beginning of value", synErr.Msg)
+
+	_, err = ParseToArrayLazy(`[1, 2`)
+	assert.True(t, errors.As(err, &synErr))
+	assert.Equal(t, "unexpected end of JSON input", synErr.Msg)
+	assert.Equal(t, 5, synErr.Offset)
+}
+
+// 测试长行只保留出错位置附近的内容
+func TestSyntaxError_LongLine(t *testing.T) {
+	input := `{"description": "0123456789012345678901234567890123456789", "x": trux, "y": "0123456789012345678901234567890123456789"}`
+	_, err := ParseToJsonObject(input)
+	var synErr *SyntaxError
+	assert.True(t, errors.As(err, &synErr))
+	assert.Equal(t, "x", synErr.Path)
+	assert.Equal(t, `...901234567890123456789", "x": trux, "y": "01234567890123456789012...`+"\n"+
+		`                                   ^`, synErr.Excerpt)
+}
+
+type strictParser struct {
+	*defaultParser
+}
+
+func (p strictParser) JsonStringToAny(jsonStr []byte, v any) error {
+	return errors.New("rejected by custom parser")
+}
+
+// 测试自定义解析器同样得到位置信息，语法正确但被解析器拒绝时返回普通错误
+func TestSyntaxError_CustomParser(t *testing.T) {
+	SetParser(strictParser{&defaultParser{}})
+	defer SetParser(&defaultParser{})
+
+	_, err := ParseToJsonObject(`{"a": 1,}`)
+	var synErr *SyntaxError
+	assert.True(t, errors.As(err, &synErr))
+	assert.Equal(t, 9, synErr.Column)
+	assert.EqualError(t, synErr.Err, "rejected by custom parser")
+
+	_, err = ParseToJsonObject(`{"a": 1}`)
+	assert.False(t, errors.As(err, &synErr))
+	assert.True(t, errors.Is(err, ErrParse))
+	assert.EqualError(t, err, "failed to parse JSON: rejected by custom parser")
+}
+
+// 测试内置解析器的解码结果与标准库一致
+func TestDecoder_Decode(t *testing.T) {
+	val, err := newDecoder([]byte(` {"s": "a\"é😀\n", "n": -1.5e2, "b": [true, false, null], "o": {}} `), nil).decode()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]any{
+		"s": "a\"é😀\n",
+		"n": -150.0,
+		"b": []any{true, false, nil},
+		"o": map[string]any{},
+	}, val)
+}
@@ -0,0 +1,264 @@
+package zjson
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+var ErrInvalidUTF8 = errors.New("invalid UTF-8")
+
+// InvalidUTF8Policy 控制字符串中的无效 UTF-8 字节和未配对的代理项（如 "\ud800"）的处理方式，
+// 同时作用于解析和序列化
+type InvalidUTF8Policy int
+
+const (
+	// UTF8Replace 替换为 U+FFFD，与 encoding/json 一致
+	UTF8Replace InvalidUTF8Policy = iota
+	// UTF8Reject 解析时返回 SyntaxError，序列化时返回错误，均可以通过 errors.Is 判断 ErrInvalidUTF8
+	UTF8Reject
+	// UTF8Preserve 按 WTF-8 保留：无效字节原样保留，未配对的代理项编码为三字节序列，
+	// 序列化时代理项还原为 \uXXXX 转义，其余无效字节原样输出
+	UTF8Preserve
+)
+
+func (c *Config) utf8Policy() InvalidUTF8Policy {
+	if c == nil {
+		return UTF8Replace
+	}
+	return c.InvalidUTF8
+}
+
+func (c *Config) normalizeNFC() bool {
+	return c != nil && c.NormalizeNFC
+}
+
+// invalidByte 处理字符串中的无效字节，调用方在 UTF8Reject 时返回错误
+func (d *decoder) invalidByte(buf *strings.Builder, c byte) error {
+	switch d.cfg.utf8Policy() {
+	case UTF8Reject:
+		return d.invalidUTF8("invalid UTF-8 byte %#x in string literal", c)
+	case UTF8Preserve:
+		buf.WriteByte(c)
+	default:
+		buf.WriteRune(utf8.RuneError)
+	}
+	return nil
+}
+
+// loneSurrogate 处理未配对的 \uXXXX 代理项，offset 为转义序列的起始位置
+func (d *decoder) loneSurrogate(buf *strings.Builder, r rune, offset int) error {
+	switch d.cfg.utf8Policy() {
+	case UTF8Reject:
+		d.pos = offset
+		return d.invalidUTF8("unpaired surrogate \\u%04x in string literal", r)
+	case UTF8Preserve:
+		buf.Write([]byte{0xe0 | byte(r>>12), 0x80 | byte(r>>6)&0x3f, 0x80 | byte(r)&0x3f})
+	default:
+		buf.WriteRune(utf8.RuneError)
+	}
+	return nil
+}
+
+func (d *decoder) invalidUTF8(format string, args ...any) error {
+	synErr := d.syntaxError(format, args...)
+	synErr.Err = ErrInvalidUTF8
+	return synErr
+}
+
+// encode 按配置序列化，默认策略直接使用解析器，其他策略需要自行处理字符串
+func (c *Config) encode(val any) ([]byte, error) {
+	if c.utf8Policy() == UTF8Replace {
+		return c.parser().AnyToJsonString(val)
+	}
+	var buf bytes.Buffer
+	if err := c.encodeValue(&buf, val); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c *Config) encodeValue(buf *bytes.Buffer, val any) error {
+	switch v := val.(type) {
+	case string:
+		return encodeString(buf, v, c.utf8Policy())
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := encodeString(buf, key, c.utf8Policy()); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			if err := c.encodeValue(buf, v[key]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+	case []any:
+		buf.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := c.encodeValue(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	}
+
+	raw, err := marshalWith(c.parser(), val)
+	if err != nil {
+		return err
+	}
+	buf.Write(raw)
+	return nil
+}
+
+const hexDigits = "0123456789abcdef"
+
+// encodeString 的转义规则与 encoding/json 保持一致
+func encodeString(buf *bytes.Buffer, s string, policy InvalidUTF8Policy) error {
+	buf.WriteByte('"')
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			switch {
+			case c == '"' || c == '\\':
+				buf.WriteByte('\\')
+				buf.WriteByte(c)
+			case c == '\n':
+				buf.WriteString(`\n`)
+			case c == '\r':
+				buf.WriteString(`\r`)
+			case c == '\t':
+				buf.WriteString(`\t`)
+			case c == '\b':
+				buf.WriteString(`\b`)
+			case c == '\f':
+				buf.WriteString(`\f`)
+			case c < 0x20 || c == '<' || c == '>' || c == '&':
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hexDigits[c>>4])
+				buf.WriteByte(hexDigits[c&0xf])
+			default:
+				buf.WriteByte(c)
+			}
+			i++
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			switch {
+			case policy == UTF8Reject:
+				return fmt.Errorf("%w: invalid byte %#x at offset %d in string", ErrInvalidUTF8, c, i)
+			case policy == UTF8Preserve:
+				if surrogate, ok := decodeWTF8Surrogate(s[i:]); ok {
+					fmt.Fprintf(buf, `\u%04x`, surrogate)
+					i += 3
+					continue
+				}
+				buf.WriteByte(c)
+			default:
+				buf.WriteString(`\ufffd`)
+			}
+			i++
+			continue
+		}
+		if r == '\u2028' || r == '\u2029' {
+			fmt.Fprintf(buf, `\u%04x`, r)
+		} else {
+			buf.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+	buf.WriteByte('"')
+	return nil
+}
+
+// decodeWTF8Surrogate 识别 WTF-8 编码的代理项，即 ED A0-BF 80-BF
+func decodeWTF8Surrogate(s string) (rune, bool) {
+	if len(s) < 3 || s[0] != 0xed || s[1] < 0xa0 || s[1] > 0xbf || s[2] < 0x80 || s[2] > 0xbf {
+		return 0, false
+	}
+	return rune(s[0]&0x0f)<<12 | rune(s[1]&0x3f)<<6 | rune(s[2]&0x3f), true
+}
+
+// NormalizeNFC 将所有键和字符串值原地转换为 Unicode NFC 形式，包括嵌套的对象和数组。
+// 规范化后相同的键只保留一个，值取原键按字典序排列时最后一个键的值。
+func (jo *JsonObject) NormalizeNFC() {
+	jo.mu.Lock()
+	defer jo.mu.Unlock()
+	normalizeMapNFC(jo.data)
+	if jo.cfg.ordered() {
+		seen := make(map[string]bool, len(jo.keys))
+		keys := jo.keys[:0]
+		for _, key := range jo.keys {
+			key = norm.NFC.String(key)
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+		jo.keys = keys
+	}
+}
+
+func (ja *JsonArray) NormalizeNFC() {
+	ja.mu.Lock()
+	defer ja.mu.Unlock()
+	for i, val := range ja.data {
+		ja.data[i] = normalizeNFC(val)
+	}
+}
+
+func normalizeMapNFC(m map[string]any) {
+	keys := sortedKeys(m)
+	vals := make([]any, len(keys))
+	for i, key := range keys {
+		vals[i] = normalizeNFC(m[key])
+	}
+	clear(m)
+	for i, key := range keys {
+		m[norm.NFC.String(key)] = vals[i]
+	}
+}
+
+func normalizeNFC(val any) any {
+	switch v := resolve(val).(type) {
+	case string:
+		return norm.NFC.String(v)
+	case map[string]any:
+		normalizeMapNFC(v)
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = normalizeNFC(item)
+		}
+		return v
+	case *JsonObject:
+		v.NormalizeNFC()
+		return v
+	case *JsonArray:
+		v.NormalizeNFC()
+		return v
+	default:
+		return v
+	}
+}
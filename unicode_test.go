@@ -0,0 +1,87 @@
+package zjson
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 测试无效 UTF-8 字节的三种处理方式
+func TestInvalidUTF8_Bytes(t *testing.T) {
+	input := "{\"name\": \"a\xffb\"}"
+
+	obj, err := Config{InvalidUTF8: UTF8Replace}.ParseToJsonObject(input)
+	assert.Nil(t, err)
+	assert.Equal(t, "a\ufffdb", obj.GetStringIgnoreError("name"))
+
+	_, err = Config{InvalidUTF8: UTF8Reject}.ParseToJsonObject(input)
+	var synErr *SyntaxError
+	assert.True(t, errors.As(err, &synErr))
+	assert.ErrorIs(t, err, ErrInvalidUTF8)
+	assert.ErrorIs(t, err, ErrParse)
+	assert.Equal(t, "name", synErr.Path)
+	assert.Equal(t, 11, synErr.Offset)
+
+	obj, err = Config{InvalidUTF8: UTF8Preserve}.ParseToJsonObject(input)
+	assert.Nil(t, err)
+	assert.Equal(t, "a\xffb", obj.GetStringIgnoreError("name"))
+	assert.Equal(t, "{\"name\":\"a\xffb\"}", obj.ToJsonStr())
+}
+
+// 测试未配对代理项的处理，保留模式下可以原样往返
+func TestInvalidUTF8_Surrogates(t *testing.T) {
+	input := `["\ud800", "x\udc00y", "😀", "\ud83dA"]`
+
+	arr, err := Config{InvalidUTF8: UTF8Replace}.ParseToArray(input)
+	assert.Nil(t, err)
+	strs, err := arr.ToStringSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"\ufffd", "x\ufffdy", "😀", "\ufffdA"}, strs)
+
+	_, err = Config{InvalidUTF8: UTF8Reject}.ParseToArray(input)
+	var synErr *SyntaxError
+	assert.True(t, errors.As(err, &synErr))
+	assert.Equal(t, 2, synErr.Offset)
+	assert.Equal(t, "unpaired surrogate \\ud800 in string literal", synErr.Msg)
+
+	arr, err = Config{InvalidUTF8: UTF8Preserve}.ParseToArray(input)
+	assert.Nil(t, err)
+	assert.Equal(t, "\xed\xa0\x80", arr.GetStringIgnoreError(0))
+	assert.Equal(t, `["\ud800","x\udc00y","😀","\ud83dA"]`, arr.ToJsonStr())
+}
+
+// 测试序列化时的处理方式，合法字符串的输出与 encoding/json 一致
+func TestInvalidUTF8_Encode(t *testing.T) {
+	obj := Config{InvalidUTF8: UTF8Reject}.NewJsonObject()
+	obj.Put("text", "<a href=\"x\">& \t\x01</a> 中文")
+	obj.Put("nested", map[string]any{"list": []any{1.5, true, nil}})
+	expected, _ := json.Marshal(map[string]any{
+		"text":   "<a href=\"x\">& \t\x01</a> 中文",
+		"nested": map[string]any{"list": []any{1.5, true, nil}},
+	})
+	assert.Equal(t, string(expected), obj.ToJsonStr())
+
+	obj.Put("bad", "\xff")
+	_, err := obj.MarshalJSON()
+	assert.ErrorIs(t, err, ErrInvalidUTF8)
+}
+
+// 测试 NFC 规范化
+func TestNormalizeNFC(t *testing.T) {
+	obj, err := Config{NormalizeNFC: true}.ParseToJsonObject(`{"cafe\u0301": ["e\u0301"]}`)
+	assert.Nil(t, err)
+	assert.Equal(t, "\u00e9", obj.GetJsonArrayIgnoreError("caf\u00e9").GetStringIgnoreError(0))
+
+	obj, _ = ParseToJsonObject(`{"cafe\u0301": {"name": "Jose\u0301"}, "list": ["e\u0301", 1]}`)
+	list := obj.GetJsonArrayIgnoreError("list")
+	obj.NormalizeNFC()
+	assert.False(t, obj.ContainsKey("cafe\u0301"))
+	assert.Equal(t, "Jos\u00e9", obj.GetJsonObjectIgnoreError("caf\u00e9").GetStringIgnoreError("name"))
+	assert.Equal(t, "\u00e9", list.GetStringIgnoreError(0))
+
+	ordered, _ := Config{KeyOrder: KeysInsertion}.ParseToJsonObject(`{"b": 1, "e\u0301": 2, "\u00e9": 3}`)
+	ordered.NormalizeNFC()
+	assert.Equal(t, "{\"b\":1,\"\u00e9\":3}", ordered.ToJsonStr())
+}
@@ -0,0 +1,106 @@
+package zjson
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJsonObject_LiveView(t *testing.T) {
+	obj, err := ParseToJsonObject(`{"a":{"b":{"c":1}},"list":[{"id":1}]}`)
+	assert.NoError(t, err)
+
+	obj.GetJsonObjectIgnoreError("a").Put("x", 1)
+	assert.Equal(t, 1, obj.GetJsonObjectIgnoreError("a").GetIntIgnoreError("x"))
+
+	// 多层视图的修改同样反映到根对象
+	obj.GetJsonObjectIgnoreError("a").GetJsonObjectIgnoreError("b").Put("c", 2)
+	obj.GetJsonArrayIgnoreError("list").Add("tail")
+	obj.GetJsonArrayIgnoreError("list").GetJsonObjectIgnoreError(0).Put("id", 2)
+	assert.JSONEq(t, `{"a":{"b":{"c":2},"x":1},"list":[{"id":2},"tail"]}`, obj.ToJsonStr())
+
+	// 多次获取返回同一实例
+	assert.Same(t, obj.GetJsonObjectIgnoreError("a"), obj.GetJsonObjectIgnoreError("a"))
+}
+
+func TestJsonObject_GetClone(t *testing.T) {
+	obj, err := ParseToJsonObject(`{"a":{"b":1},"list":[1,2]}`)
+	assert.NoError(t, err)
+
+	a, err := obj.GetJsonObjectClone("a")
+	assert.NoError(t, err)
+	a.Put("b", 2)
+	list, err := obj.GetJsonArrayClone("list")
+	assert.NoError(t, err)
+	list.Add(3)
+	assert.JSONEq(t, `{"a":{"b":1},"list":[1,2]}`, obj.ToJsonStr())
+
+	_, err = obj.GetJsonObjectClone("missing")
+	assert.Error(t, err)
+}
+
+func TestJsonArray_LiveView(t *testing.T) {
+	arr, err := ParseToArray(`[{"id":1},[1]]`)
+	assert.NoError(t, err)
+
+	arr.GetJsonObjectIgnoreError(0).Put("name", "Alice")
+	arr.GetJsonArrayIgnoreError(1).Add(2)
+	assert.JSONEq(t, `[{"id":1,"name":"Alice"},[1,2]]`, arr.ToJsonStr())
+
+	clone, err := arr.GetJsonObjectClone(0)
+	assert.NoError(t, err)
+	clone.Put("id", 9)
+	assert.Equal(t, 1, arr.GetJsonObjectIgnoreError(0).GetIntIgnoreError("id"))
+
+	_, err = arr.GetJsonArray(5)
+	assert.Error(t, err)
+}
+
+// 测试并发获取视图
+func TestJsonObject_LiveViewConcurrency(t *testing.T) {
+	obj, err := ParseToJsonObject(`{"counter":{}}`)
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			obj.GetJsonObjectIgnoreError("counter").Put(string(rune('a'+i)), i)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 10, obj.GetJsonObjectIgnoreError("counter").Length())
+}
+
+// 测试创建视图前后 Get、Lookup 和迭代器返回的类型一致
+func TestJsonObject_GetAfterView(t *testing.T) {
+	obj, err := ParseToJsonObject(`{"a":{"b":1},"list":[{"id":1}]}`)
+	assert.NoError(t, err)
+	before := obj.Get("a")
+
+	obj.GetJsonObjectIgnoreError("a").Put("c", 2)
+	obj.GetJsonArrayIgnoreError("list").GetJsonObjectIgnoreError(0)
+	assert.IsType(t, before, obj.Get("a"))
+	assert.Equal(t, map[string]any{"b": 1.0, "c": 2}, obj.Get("a"))
+	assert.Equal(t, []any{map[string]any{"id": 1.0}}, obj.Get("list"))
+	val, _ := obj.Lookup("a")
+	assert.IsType(t, map[string]any{}, val)
+	for _, val := range obj.All() {
+		_, isObject := val.(*JsonObject)
+		_, isArray := val.(*JsonArray)
+		assert.False(t, isObject || isArray)
+	}
+	assert.IsType(t, map[string]any{}, obj.GetJsonArrayIgnoreError("list").Get(0))
+
+	// 对象和数组转换为字符串时输出 JSON
+	assert.Equal(t, `{"b":1,"c":2}`, obj.GetStringIgnoreError("a"))
+	assert.Equal(t, `[{"id":1}]`, obj.GetStringIgnoreError("list"))
+
+	lazy, err := ParseToJsonObjectLazy(`{"a":{"b":[1]}}`)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"b": []any{1.0}}, lazy.Get("a"))
+	assert.Equal(t, `{"b":[1]}`, lazy.GetStringIgnoreError("a"))
+}